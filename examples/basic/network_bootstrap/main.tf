@@ -40,6 +40,7 @@ resource "quorum_bootstrap_keystore" "node" {
   dynamic "account" {
     for_each = var.nodes_config[count.index].accounts
     content {
+      name       = tostring(account.key)
       passphrase = lookup(account.value, "passphrase", "")
       balance    = lookup(account.value, "balance", "1000000000000000000000000000")
     }
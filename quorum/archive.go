@@ -0,0 +1,164 @@
+package quorum
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const checksumsFileName = "SHA256SUMS"
+
+// file names which contain private keys
+var secretFilePatterns = []string{"keystore", nodeKeyFileName, "*.key"}
+
+type archiveEntry struct {
+	name string // slash separated path inside the archive
+	file string // path in the file system, empty for directories
+	mode os.FileMode
+}
+
+// collect the entries under root, sorted by name, prefixing them with prefix.
+// A path is skipped, including its children, if exclude returns true for its path relative to root
+func collectArchiveEntries(root string, prefix string, exclude func(rel string, name string) bool) ([]*archiveEntry, error) {
+	entries := make([]*archiveEntry, 0)
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if exclude(filepath.ToSlash(rel), info.Name()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		e := &archiveEntry{name: path.Join(prefix, filepath.ToSlash(rel)), mode: info.Mode().Perm()}
+		if info.IsDir() {
+			e.name += "/"
+		} else if info.Mode().IsRegular() {
+			e.file = p
+		} else {
+			// sockets, symlinks and devices are not portable
+			return nil
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+// digest of the paths, modes and file contents of the entries of every archive, used to detect changes of the sources
+func archiveEntriesChecksum(archives map[string][]*archiveEntry) (string, error) {
+	names := make([]string, 0, len(archives))
+	for name := range archives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		_, _ = fmt.Fprintf(h, "%s\n", name)
+		for _, e := range archives[name] {
+			var checksum string
+			if e.file != "" {
+				var err error
+				if checksum, err = fileSHA256(e.file); err != nil {
+					return "", err
+				}
+			}
+			_, _ = fmt.Fprintf(h, "%s %o %s\n", e.name, e.mode, checksum)
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// write a tar.gz archive with the entries and a SHA256SUMS file listing the checksum of every file.
+// Timestamps and ownership are fixed so the same entries always produce the same archive.
+// Returns the SHA-256 checksum of the archive
+func writeArchive(dst string, prefix string, entries []*archiveEntry) (string, error) {
+	var checksums bytes.Buffer
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	gw, err := gzip.NewWriterLevel(io.MultiWriter(f, h), gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	tw := tar.NewWriter(gw)
+	writeEntry := func(name string, mode os.FileMode, content []byte) error {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    int64(mode),
+			ModTime: time.Unix(0, 0),
+			Format:  tar.FormatPAX,
+		}
+		if strings.HasSuffix(name, "/") {
+			hdr.Typeflag = tar.TypeDir
+		} else {
+			hdr.Typeflag = tar.TypeReg
+			hdr.Size = int64(len(content))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+	for _, e := range entries {
+		var content []byte
+		if e.file != "" {
+			if content, err = ioutil.ReadFile(e.file); err != nil {
+				return "", err
+			}
+			_, _ = fmt.Fprintf(&checksums, "%x  %s\n", sha256.Sum256(content), e.name)
+		}
+		if err := writeEntry(e.name, e.mode, content); err != nil {
+			return "", fmt.Errorf("can't archive %s due to %s", e.name, err)
+		}
+	}
+	if err := writeEntry(path.Join(prefix, checksumsFileName), 0644, checksums.Bytes()); err != nil {
+		return "", err
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gw.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func fileSHA256(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
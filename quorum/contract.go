@@ -0,0 +1,81 @@
+package quorum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// contractBackend is implemented by ethclient.Client and the simulated backend
+type contractBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+type contractDeployment struct {
+	code        []byte   // bytecode followed by ABI-encoded constructor arguments
+	gasLimit    uint64   // 0 to estimate
+	gasPrice    *big.Int // nil to use the suggested gas price
+	privateFor  []string // non-empty for private transactions
+	privateFrom string
+}
+
+// deployContract sends the contract creation transaction signed by key and waits for the receipt.
+// The payload of a private transaction is stored in the transaction manager configured in the backend
+func deployContract(ctx context.Context, backend contractBackend, key *ecdsa.PrivateKey, dep *contractDeployment) (*types.Receipt, error) {
+	opts := bind.NewKeyedTransactor(key)
+	opts.Context = ctx
+	opts.GasLimit = dep.gasLimit
+	opts.GasPrice = dep.gasPrice
+	if len(dep.privateFor) > 0 {
+		opts.PrivateFor = dep.privateFor
+		opts.PrivateFrom = dep.privateFrom
+	}
+	_, tx, _, err := bind.DeployContract(opts, abi.ABI{}, dep.code, backend)
+	if err != nil {
+		return nil, fmt.Errorf("can't send transaction due to %s", err)
+	}
+	receipt, err := bind.WaitMined(ctx, backend, tx)
+	if err != nil {
+		return nil, fmt.Errorf("can't get receipt of transaction %s due to %s", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s failed", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// findKeyFile returns the file in the keystore directory holding the key of the address
+func findKeyFile(dir string, address common.Address) (string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		file := filepath.Join(dir, f.Name())
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		var key struct {
+			Address string `json:"address"`
+		}
+		if json.Unmarshal(content, &key) == nil && common.IsHexAddress(key.Address) && common.HexToAddress(key.Address) == address {
+			return file, nil
+		}
+	}
+	return "", fmt.Errorf("no key file for %s in %s", address.Hex(), dir)
+}
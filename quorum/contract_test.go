@@ -0,0 +1,85 @@
+package quorum
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// creation bytecode of a contract whose runtime code returns 42
+const (
+	testContractBytecode    = "0x600a80600c6000396000f3fe602a60005260206000f3"
+	testContractRuntimeCode = "0x602a60005260206000f3"
+)
+
+// mine a block for every transaction
+type autoCommitBackend struct {
+	*backends.SimulatedBackend
+}
+
+func (b *autoCommitBackend) SendTransaction(ctx context.Context, tx *types.Transaction, args bind.PrivateTxArgs) error {
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx, args); err != nil {
+		return err
+	}
+	b.Commit()
+	return nil
+}
+
+func TestDeployContract(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	backend := &autoCommitBackend{backends.NewSimulatedBackend(core.GenesisAlloc{from: {Balance: big.NewInt(1e18)}}, 8000000)}
+
+	receipt, err := deployContract(context.Background(), backend, key, &contractDeployment{code: hexutil.MustDecode(testContractBytecode)})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, crypto.CreateAddress(from, 0), receipt.ContractAddress)
+	code, err := backend.CodeAt(context.Background(), receipt.ContractAddress, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, testContractRuntimeCode, hexutil.Encode(code))
+}
+
+func TestDeployContract_whenFailed(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	backend := &autoCommitBackend{backends.NewSimulatedBackend(core.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: big.NewInt(1e18)}}, 8000000)}
+
+	_, err := deployContract(context.Background(), backend, key, &contractDeployment{code: []byte{0xfe}, gasLimit: 100000})
+
+	assert.Regexp(t, "^transaction 0x[0-9a-f]{64} failed$", err)
+}
+
+func TestFindKeyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testkeystore-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	acc, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := findKeyFile(dir, acc.Address)
+
+	assert.NoError(t, err)
+	assert.Equal(t, acc.URL.Path, file)
+
+	_, err = findKeyFile(dir, common.HexToAddress("0x01"))
+
+	assert.Error(t, err)
+}
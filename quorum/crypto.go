@@ -2,6 +2,7 @@ package quorum
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -117,3 +118,55 @@ func toKeyDataJSON(password string, aOpts *argonOptions, privateKey []byte, pubK
 	}
 	return string(rawJson), string(privateKeyJson), nil
 }
+
+// secretsBundle is the plaintext of an encrypted secrets bundle
+type secretsBundle struct {
+	Version      int                        `json:"version"`
+	NodeKey      string                     `json:"nodeKey,omitempty"`
+	TmPrivateKey json.RawMessage            `json:"tmPrivateKey,omitempty"`
+	Keystore     map[string]json.RawMessage `json:"keystore,omitempty"`
+}
+
+// SHA-256 checksum in hex of the bundle content. Keystore files are sorted by name so it is deterministic
+func (b *secretsBundle) checksum() (string, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// encrypt the bundle using NaCl sealed box (libsodium crypto_box_seal) for the recipient Curve25519 public key
+func sealSecretsBundle(recipient StandardBase64EncodedString, bundle *secretsBundle) (StandardBase64EncodedString, error) {
+	pub, err := recipient.bytes()
+	if err != nil || len(pub) != 32 {
+		return "", fmt.Errorf("recipient public key must be 32 bytes in standard base64 encoding")
+	}
+	var recipientKey [32]byte
+	copy(recipientKey[:], pub)
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := box.SealAnonymous(nil, plaintext, &recipientKey, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return toStandardBase64EncodedString(sealed), nil
+}
+
+func openSecretsBundle(sealed StandardBase64EncodedString, publicKey, privateKey *[32]byte) (*secretsBundle, error) {
+	raw, err := sealed.bytes()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, ok := box.OpenAnonymous(nil, raw, publicKey, privateKey)
+	if !ok {
+		return nil, fmt.Errorf("can't decrypt the bundle")
+	}
+	var bundle secretsBundle
+	if err := json.Unmarshal(plaintext, &bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
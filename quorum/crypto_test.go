@@ -61,3 +61,28 @@ func TestEncodePrivateKey_whenUsingPassword(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, priv[:], actual[:])
 }
+
+func TestSealSecretsBundle_whenTypical(t *testing.T) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle := &secretsBundle{
+		Version:      1,
+		NodeKey:      "0123",
+		TmPrivateKey: json.RawMessage(`{"type":"unlocked"}`),
+	}
+
+	sealed, err := sealSecretsBundle(toStandardBase64EncodedString(pub[:]), bundle)
+	assert.NoError(t, err)
+	actual, err := openSecretsBundle(sealed, pub, priv)
+
+	assert.NoError(t, err)
+	assert.Equal(t, bundle, actual)
+}
+
+func TestSealSecretsBundle_whenInvalidRecipient(t *testing.T) {
+	_, err := sealSecretsBundle("AAAA", &secretsBundle{})
+
+	assert.EqualError(t, err, "recipient public key must be 32 bytes in standard base64 encoding")
+}
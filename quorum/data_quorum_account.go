@@ -0,0 +1,102 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this data source to read balance, nonce and code of an account at a block via JSON-RPC
+// using `eth_getBalance`, `eth_getTransactionCount` and `eth_getCode`, e.g. to verify genesis funding.
+//
+// Numbers are returned as decimal and hex strings as they may not fit in a number attribute.
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccountRead,
+		Schema: map[string]*schema.Schema{
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of the node. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"address": {
+				Type:         schema.TypeString,
+				Description:  "Address of the account",
+				Required:     true,
+				ValidateFunc: validateAddress,
+			},
+			"block": {
+				Type:         schema.TypeString,
+				Description:  "Block number in decimal or hex, or one of `latest`, `earliest` and `pending`",
+				Optional:     true,
+				Default:      "latest",
+				ValidateFunc: validateBlockNumber,
+			},
+			"balance": {
+				Type:        schema.TypeString,
+				Description: "Balance in wei as decimal",
+				Computed:    true,
+			},
+			"balance_hex": {
+				Type:        schema.TypeString,
+				Description: "Balance in wei as hex",
+				Computed:    true,
+			},
+			"nonce": {
+				Type:        schema.TypeString,
+				Description: "Number of transactions sent from the account as decimal",
+				Computed:    true,
+			},
+			"nonce_hex": {
+				Type:        schema.TypeString,
+				Description: "Number of transactions sent from the account as hex",
+				Computed:    true,
+			},
+			"code": {
+				Type:        schema.TypeString,
+				Description: "Contract code as hex. `0x` if the account is not a contract",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	block, err := toBlockNumberArg(d.Get("block").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("block"), err)
+	}
+	address := common.HexToAddress(d.Get("address").(string))
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	var (
+		balance hexutil.Big
+		nonce   hexutil.Uint64
+		code    hexutil.Bytes
+	)
+	if err := client.CallContext(ctx, &balance, "eth_getBalance", address, block); err != nil {
+		return diag.Errorf("eth_getBalance failed due to %s", err)
+	}
+	if err := client.CallContext(ctx, &nonce, "eth_getTransactionCount", address, block); err != nil {
+		return diag.Errorf("eth_getTransactionCount failed due to %s", err)
+	}
+	if err := client.CallContext(ctx, &code, "eth_getCode", address, block); err != nil {
+		return diag.Errorf("eth_getCode failed due to %s", err)
+	}
+	d.SetId(fmt.Sprintf("%s@%s", address.Hex(), block))
+	_ = d.Set("balance", balance.ToInt().String())
+	_ = d.Set("balance_hex", balance.String())
+	_ = d.Set("nonce", fmt.Sprintf("%d", uint64(nonce)))
+	_ = d.Set("nonce_hex", nonce.String())
+	_ = d.Set("code", code.String())
+	return nil
+}
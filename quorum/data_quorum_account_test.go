@@ -0,0 +1,72 @@
+package quorum
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// @example
+func TestAccDataSourceAccount_whenTypical(t *testing.T) {
+	_, endpoint := newTestSimulatedRPCServer(t, core.GenesisAlloc{
+		common.HexToAddress("0x0638e1574728b6d862dd5d3a3e0942c3be47d996"): {Balance: new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil), Nonce: 5},
+		common.HexToAddress("0x0000000000000000000000000000000000008888"): {Balance: common.Big0, Code: hexutil.MustDecode(testContractRuntimeCode)},
+	})
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "quorum" {
+  rpc_endpoint = "%s"
+}
+
+data "quorum_account" "funded" {
+  address = "0x0638e1574728b6d862dd5d3a3e0942c3be47d996"
+}
+
+data "quorum_account" "contract" {
+  address = "0x0000000000000000000000000000000000008888"
+  block   = "0"
+}
+`, endpoint),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.quorum_account.funded", "id", "0x0638E1574728b6D862dd5d3A3E0942c3be47D996@latest"),
+					resource.TestCheckResourceAttr("data.quorum_account.funded", "balance", "1000000000000000000000000000"),
+					resource.TestCheckResourceAttr("data.quorum_account.funded", "balance_hex", "0x33b2e3c9fd0803ce8000000"),
+					resource.TestCheckResourceAttr("data.quorum_account.funded", "nonce", "5"),
+					resource.TestCheckResourceAttr("data.quorum_account.funded", "nonce_hex", "0x5"),
+					resource.TestCheckResourceAttr("data.quorum_account.funded", "code", "0x"),
+					resource.TestCheckResourceAttr("data.quorum_account.contract", "id", "0x0000000000000000000000000000000000008888@0x0"),
+					resource.TestCheckResourceAttr("data.quorum_account.contract", "balance", "0"),
+					resource.TestCheckResourceAttr("data.quorum_account.contract", "code", testContractRuntimeCode),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAccount_whenInvalidBlock(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "quorum_account" "test" {
+  address = "0x0638e1574728b6d862dd5d3a3e0942c3be47d996"
+  block   = "finalized"
+}
+`,
+				ExpectError: regexp.MustCompile("expect latest, earliest, pending or a block number"),
+			},
+		},
+	})
+}
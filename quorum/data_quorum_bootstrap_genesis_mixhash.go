@@ -1,19 +1,21 @@
 package quorum
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Use this data source to obtain `MixHash` value being used in the genesis file.
 // Especially when using Istanbul consensus algorithm which defines a constant digest value.
 func dataSourceBootstrapGenesisMixHash() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBootstrapGenesisMixHashRead,
+		ReadContext: dataSourceBootstrapGenesisMixHashRead,
 		Schema: map[string]*schema.Schema{
 			"istanbul": {
 				Type:        schema.TypeString,
@@ -24,8 +26,9 @@ func dataSourceBootstrapGenesisMixHash() *schema.Resource {
 	}
 }
 
-func dataSourceBootstrapGenesisMixHashRead(d *schema.ResourceData, _ interface{}) error {
-	d.SetId(fmt.Sprintf("%d", time.Now().Unix()))
-	_ = d.Set("istanbul", strings.ToLower(types.IstanbulDigest.String()))
+func dataSourceBootstrapGenesisMixHashRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	istanbul := strings.ToLower(types.IstanbulDigest.String())
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte("istanbul="+istanbul))))
+	_ = d.Set("istanbul", istanbul)
 	return nil
 }
@@ -3,7 +3,7 @@ package quorum
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 // @example
@@ -23,6 +23,7 @@ output "istanbul_mix_hash" {
                 `,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.quorum_bootstrap_genesis_mixhash.test", "istanbul"),
+					resource.TestCheckResourceAttr("data.quorum_bootstrap_genesis_mixhash.test", "id", "08613f67ffed9b7ebadd29dc6e0d427d023100125dfa3435135280e0b1ad79cb"),
 					resource.TestCheckOutput("istanbul_mix_hash", "0x63746963616c2062797a616e74696e65206661756c7420746f6c6572616e6365"),
 				),
 			},
@@ -0,0 +1,60 @@
+package quorum
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this data source to compute `extradata` field used in the genesis file without managing any state.
+//
+// The value is available during plan when all `istanbul_addresses` are known, hence genesis content can be reviewed before apply.
+func dataSourceBootstrapIstanbulExtradata() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBootstrapIstanbulExtradataRead,
+		Schema: map[string]*schema.Schema{
+			"istanbul_addresses": {
+				Type:        schema.TypeList,
+				Description: "list of Istanbul address to construct extradata",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    1,
+				Required:    true,
+			},
+			"mode": {
+				Type:        schema.TypeString,
+				Description: "generate extradata using RLP encoding mode. Supported: ibft1, ibft2 and qbft. Default is ibft1",
+				Optional:    true,
+				Default:     Ibft1,
+			},
+			"vanity": {
+				Type:        schema.TypeString,
+				Description: "Vanity Hex Value to be included in the extradata",
+				Optional:    true,
+				Default:     "0x00",
+			},
+			"extradata": {
+				Type:        schema.TypeString,
+				Description: "Computed value which can be used in genesis file",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceBootstrapIstanbulExtradataRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	validators, diags := toValidators(d.Get("istanbul_addresses").([]interface{}))
+	if diags.HasError() {
+		return diags
+	}
+	vanity := d.Get("vanity").(string)
+	mode := d.Get("mode").(string)
+
+	extradata, err := createIstanbulExtraData(validators, mode, vanity)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("extradata", extradata)
+	d.SetId(extradataID(validators, mode, vanity))
+	return nil
+}
@@ -0,0 +1,65 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// @example
+func TestAccDataSourceBootstrapIstanbulExtradata_whenTypical(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_bootstrap_node_key" "test" {
+  count = 3
+}
+
+data "quorum_bootstrap_istanbul_extradata" "test" {
+  istanbul_addresses = quorum_bootstrap_node_key.test.*.istanbul_address
+  mode               = "qbft"
+}
+                `,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.quorum_bootstrap_istanbul_extradata.test", "extradata"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceBootstrapIstanbulExtradata_whenSameAsResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+locals {
+  addresses = [
+    "0x8f1e6d8303716516cc9e562e66d09721752a1f83",
+    "0x95167bde9c4c3b12180945bbee9900f69d9ea558",
+    "0xa7c1d1b572f11b02cd6fadc21f1e51f399b4d4cb"
+  ]
+}
+
+data "quorum_bootstrap_istanbul_extradata" "test" {
+  istanbul_addresses = local.addresses
+}
+
+resource "quorum_bootstrap_istanbul_extradata" "test" {
+  istanbul_addresses = local.addresses
+}
+                `,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.quorum_bootstrap_istanbul_extradata.test", "extradata", "0x0000000000000000000000000000000000000000000000000000000000000000f885f83f948f1e6d8303716516cc9e562e66d09721752a1f839495167bde9c4c3b12180945bbee9900f69d9ea55894a7c1d1b572f11b02cd6fadc21f1e51f399b4d4cbb8410000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000c0"),
+					resource.TestCheckResourceAttrPair("data.quorum_bootstrap_istanbul_extradata.test", "extradata", "quorum_bootstrap_istanbul_extradata.test", "extradata"),
+					resource.TestCheckResourceAttrPair("data.quorum_bootstrap_istanbul_extradata.test", "id", "quorum_bootstrap_istanbul_extradata.test", "id"),
+				),
+			},
+		},
+	})
+}
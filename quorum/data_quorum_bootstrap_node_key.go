@@ -1,11 +1,14 @@
 package quorum
 
 import (
+	"context"
 	"encoding/hex"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/enode"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Use this data source to obtain various information parsed from an existing node key in hex format.
@@ -13,7 +16,7 @@ import (
 // Node key encodes a private key that defines an identity of a Quorum node in the network. It is primarily used in P2P networking.
 func dataSourceBootstrapNodeKey() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceBootstrapNodeKeyRead,
+		ReadContext: dataSourceBootstrapNodeKeyRead,
 		Schema: map[string]*schema.Schema{
 			"node_key_hex": {
 				Type:        schema.TypeString,
@@ -39,13 +42,13 @@ func dataSourceBootstrapNodeKey() *schema.Resource {
 	}
 }
 
-func dataSourceBootstrapNodeKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceBootstrapNodeKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	nodeKeyHex := d.Get("node_key_hex").(string)
 	nodeKey, err := crypto.HexToECDSA(nodeKeyHex)
 	if err != nil {
-		return err
+		return attributeDiag(cty.GetAttrPath("node_key_hex"), err)
 	}
 	d.SetId(enode.PubkeyToIDV4(&nodeKey.PublicKey).String())
 	_ = d.Set("node_key_hex", hex.EncodeToString(crypto.FromECDSA(nodeKey)))
-	return resourceBootstrapNodeKeyRead(d, meta)
+	return resourceBootstrapNodeKeyRead(ctx, d, meta)
 }
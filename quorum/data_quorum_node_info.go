@@ -0,0 +1,134 @@
+package quorum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this data source to read information about a running node via JSON-RPC using `admin_nodeInfo`, `net_peerCount`,
+// `eth_blockNumber` and `eth_chainId`. `admin` and `net` RPC APIs must be enabled in the node.
+//
+// `chain_id` is 0 with a warning if the node does not support `eth_chainId`.
+func dataSourceNodeInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNodeInfoRead,
+		Schema: map[string]*schema.Schema{
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of the node. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"node_id": {
+				Type:        schema.TypeString,
+				Description: "Node ID as hex",
+				Computed:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the node including client type, version and OS",
+				Computed:    true,
+			},
+			"enode": {
+				Type:        schema.TypeString,
+				Description: "Enode URL of the node",
+				Computed:    true,
+			},
+			"ip": {
+				Type:        schema.TypeString,
+				Description: "IP address of the node",
+				Computed:    true,
+			},
+			"listen_addr": {
+				Type:        schema.TypeString,
+				Description: "P2P listening address",
+				Computed:    true,
+			},
+			"p2p_port": {
+				Type:        schema.TypeInt,
+				Description: "TCP listening port for P2P",
+				Computed:    true,
+			},
+			"discovery_port": {
+				Type:        schema.TypeInt,
+				Description: "UDP listening port for discovery",
+				Computed:    true,
+			},
+			"protocols_json": {
+				Type:        schema.TypeString,
+				Description: "Protocols information in JSON",
+				Computed:    true,
+			},
+			"peer_count": {
+				Type:        schema.TypeInt,
+				Description: "Number of connected peers",
+				Computed:    true,
+			},
+			"block_number": {
+				Type:        schema.TypeInt,
+				Description: "Current block number",
+				Computed:    true,
+			},
+			"chain_id": {
+				Type:        schema.TypeInt,
+				Description: "Chain ID of the network",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceNodeInfoRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	var (
+		info        p2p.NodeInfo
+		peerCount   hexutil.Uint
+		blockNumber hexutil.Uint64
+		chainID     hexutil.Big
+	)
+	if err := client.CallContext(ctx, &info, "admin_nodeInfo"); err != nil {
+		return diag.Errorf("admin_nodeInfo failed due to %s", err)
+	}
+	if err := client.CallContext(ctx, &peerCount, "net_peerCount"); err != nil {
+		return diag.Errorf("net_peerCount failed due to %s", err)
+	}
+	if err := client.CallContext(ctx, &blockNumber, "eth_blockNumber"); err != nil {
+		return diag.Errorf("eth_blockNumber failed due to %s", err)
+	}
+	var diags diag.Diagnostics
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("eth_chainId failed due to %s", err),
+			AttributePath: cty.GetAttrPath("chain_id"),
+		})
+	}
+	protocols, err := json.Marshal(info.Protocols)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(info.ID)
+	_ = d.Set("node_id", info.ID)
+	_ = d.Set("name", info.Name)
+	_ = d.Set("enode", info.Enode)
+	_ = d.Set("ip", info.IP)
+	_ = d.Set("listen_addr", info.ListenAddr)
+	_ = d.Set("p2p_port", info.Ports.Listener)
+	_ = d.Set("discovery_port", info.Ports.Discovery)
+	_ = d.Set("protocols_json", string(protocols))
+	_ = d.Set("peer_count", int(peerCount))
+	_ = d.Set("block_number", int(blockNumber))
+	_ = d.Set("chain_id", chainID.ToInt().Int64())
+	return diags
+}
@@ -0,0 +1,77 @@
+package quorum
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// @example
+func TestAccDataSourceNodeInfo_whenTypical(t *testing.T) {
+	endpoint := newTestRPCServer(t, big.NewInt(10))
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "quorum" {
+  rpc_endpoint = "%s"
+}
+
+data "quorum_node_info" "test" {
+}
+`, endpoint),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.quorum_node_info.test", "id", "data.quorum_node_info.test", "node_id"),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "ip", "127.0.0.1"),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "p2p_port", "21000"),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "protocols_json", `{"istanbul":{"network":10}}`),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "peer_count", "3"),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "block_number", "42"),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "chain_id", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNodeInfo_whenChainIdNotSupported(t *testing.T) {
+	endpoint := newTestRPCServer(t, nil)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "quorum_node_info" "test" {
+  rpc_endpoint = "%s"
+}
+`, endpoint),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "block_number", "42"),
+					resource.TestCheckResourceAttr("data.quorum_node_info.test", "chain_id", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceNodeInfo_whenNoEndpoint(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "quorum_node_info" "test" {
+}
+`,
+				ExpectError: regexp.MustCompile("rpc_endpoint is required"),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+package quorum
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this data source to check a running Tessera node via its `/upcheck`, `/version` and `/partyinfo` endpoints.
+// Reading fails if the node is not up.
+//
+// `public_keys` lists all public keys known to the node, including keys of its peers, so it can be used to assert
+// `public_key_b64` of `quorum_transaction_manager_keypair` is visible across the network.
+func dataSourceTesseraPartyInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTesseraPartyInfoRead,
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:         schema.TypeString,
+				Description:  "URL of the Tessera P2P server, e.g.: `http://localhost:9001`, or path of its unix socket prefixed by `unix:`, e.g.: `unix:/data/p2p.ipc`. The Q2T socket (`tm.ipc`) does not serve `/partyinfo` and can't be used",
+				Required:     true,
+				ValidateFunc: validateTesseraURL,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "Version of Tessera",
+				Computed:    true,
+			},
+			"node_url": {
+				Type:        schema.TypeString,
+				Description: "URL which the node advertises to its peers",
+				Computed:    true,
+			},
+			"peer_urls": {
+				Type:        schema.TypeList,
+				Description: "Sorted URLs of peers known to the node",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"public_keys": {
+				Type:        schema.TypeList,
+				Description: "Sorted public keys in base64 known to the node",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"public_key_urls": {
+				Type:        schema.TypeMap,
+				Description: "URLs of the nodes owning the public keys, keyed by public keys",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTesseraPartyInfoRead(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	targetURL := d.Get("url").(string)
+	client, err := newTesseraClient(targetURL)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("url"), err)
+	}
+	defer client.close()
+	if _, err := client.get(ctx, "/upcheck"); err != nil {
+		return attributeDiag(cty.GetAttrPath("url"), err)
+	}
+	version, err := client.get(ctx, "/version")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rawPartyInfo, err := client.get(ctx, "/partyinfo")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var partyInfo tesseraPartyInfo
+	if err := json.Unmarshal(rawPartyInfo, &partyInfo); err != nil {
+		return diag.Errorf("invalid partyinfo due to %s", err)
+	}
+	peerURLs := make([]string, 0, len(partyInfo.Peers))
+	for _, p := range partyInfo.Peers {
+		peerURLs = append(peerURLs, p.URL)
+	}
+	sort.Strings(peerURLs)
+	keyURLs := make(map[string]interface{}, len(partyInfo.Keys))
+	publicKeys := make([]string, 0, len(partyInfo.Keys))
+	for _, k := range partyInfo.Keys {
+		if _, ok := keyURLs[k.Key]; !ok {
+			publicKeys = append(publicKeys, k.Key)
+		}
+		keyURLs[k.Key] = k.URL
+	}
+	sort.Strings(publicKeys)
+	d.SetId(targetURL)
+	_ = d.Set("version", strings.TrimSpace(string(version)))
+	_ = d.Set("node_url", partyInfo.URL)
+	_ = d.Set("peer_urls", peerURLs)
+	_ = d.Set("public_keys", publicKeys)
+	_ = d.Set("public_key_urls", keyURLs)
+	return nil
+}
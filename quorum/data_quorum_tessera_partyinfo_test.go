@@ -0,0 +1,80 @@
+package quorum
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// @example
+func TestAccDataSourceTesseraPartyInfo_whenTypical(t *testing.T) {
+	srv := httptest.NewServer(newTestTesseraHandler(true))
+	defer srv.Close()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "quorum_tessera_partyinfo" "test" {
+  url = "%s"
+}
+`, srv.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "version", "21.7.2"),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "node_url", "http://tm1:9001/"),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "peer_urls.#", "2"),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "peer_urls.0", "http://tm1:9001/"),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "peer_urls.1", "http://tm2:9001/"),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "public_keys.#", "2"),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "public_keys.0", testTesseraKey1),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "public_keys.1", testTesseraKey2),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "public_key_urls."+testTesseraKey2, "http://tm2:9001/"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceTesseraPartyInfo_whenUnixSocket(t *testing.T) {
+	endpoint := newTestUnixServer(t, newTestTesseraHandler(true))
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "quorum_tessera_partyinfo" "test" {
+  url = "%s"
+}
+`, endpoint),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "id", endpoint),
+					resource.TestCheckResourceAttr("data.quorum_tessera_partyinfo.test", "public_keys.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceTesseraPartyInfo_whenNotUp(t *testing.T) {
+	srv := httptest.NewServer(newTestTesseraHandler(false))
+	defer srv.Close()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "quorum_tessera_partyinfo" "test" {
+  url = "%s"
+}
+`, srv.URL),
+				ExpectError: regexp.MustCompile("GET /upcheck returned 503"),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package quorum
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// istanbulDiscardTimeout bounds discarding a proposal after the resource timeout is reached
+const istanbulDiscardTimeout = 30 * time.Second
+
+// createIstanbulExtraData returns the hex encoded extraData for the given consensus mode.
+// Unknown modes fall back to ibft1
+func createIstanbulExtraData(validators []common.Address, mode string, vanity string) (string, error) {
+	createFunc := createIbft1ExtraData
+	switch mode {
+	case Ibft2:
+		createFunc = createIbft2ExtraData
+	case Qbft:
+		createFunc = createQbftExtraData
+	}
+	payload, err := createFunc(validators, vanity)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + common.Bytes2Hex(payload), nil
+}
+
+// extradataID derives a stable ID from the inputs of the extraData
+func extradataID(validators []common.Address, mode string, vanity string) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s:%s", mode, vanity)
+	for _, v := range validators {
+		_, _ = h.Write(v.Bytes())
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func createIbft1ExtraData(validators []common.Address, vanity string) ([]byte, error) {
+	ist := &types.IstanbulExtra{
+		Validators:    validators,
+		Seal:          make([]byte, types.IstanbulExtraSeal),
+		CommittedSeal: [][]byte{},
+	}
+	payload, err := rlp.EncodeToBytes(&ist)
+	if err != nil {
+		return nil, err
+	}
+
+	newVanity, err := hexutil.Decode(vanity)
+	if err != nil {
+		return nil, err
+	}
+	if len(newVanity) < types.IstanbulExtraVanity {
+		newVanity = append(newVanity, bytes.Repeat([]byte{0x00}, types.IstanbulExtraVanity-len(newVanity))...)
+	}
+	newVanity = newVanity[:types.IstanbulExtraVanity]
+	return append(newVanity, payload...), nil
+}
+
+func createIbft2ExtraData(validators []common.Address, _ string) ([]byte, error) {
+	data := &BesuExtraData{
+		Vanity:      make([]byte, 32),
+		Validators:  validators,
+		RoundNumber: make([]byte, 4),
+	}
+
+	return rlp.EncodeToBytes(data)
+}
+
+// createQbftExtraData generates qbft consensus compatible extraData
+func createQbftExtraData(validators []common.Address, _ string) ([]byte, error) {
+	data := &QbftExtraData{
+		Vanity:     make([]byte, 32),
+		Validators: validators,
+	}
+	return rlp.EncodeToBytes(data)
+}
+
+// isIstanbulValidator checks if the address is a validator at the latest block as seen by the node
+func isIstanbulValidator(ctx context.Context, client *rpc.Client, address common.Address) (bool, error) {
+	var validators []common.Address
+	if err := client.CallContext(ctx, &validators, "istanbul_getValidators", "latest"); err != nil {
+		return false, fmt.Errorf("istanbul_getValidators failed due to %s", err)
+	}
+	for _, v := range validators {
+		if v == address {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// proposeIstanbulValidator votes to add (auth is true) or remove the address on every node,
+// waits until all nodes see the change then discards the proposal so the nodes stop voting.
+// The proposal is discarded on every exit path, using a fresh context as ctx may be already expired
+func proposeIstanbulValidator(ctx context.Context, clients []*rpc.Client, endpoints []string, address common.Address, auth bool, interval time.Duration) (err error) {
+	defer func() {
+		discardCtx, cancel := context.WithTimeout(context.Background(), istanbulDiscardTimeout)
+		defer cancel()
+		for i, client := range clients {
+			if discardErr := client.CallContext(discardCtx, nil, "istanbul_discard", address); discardErr != nil {
+				if err == nil {
+					err = fmt.Errorf("istanbul_discard failed on %s due to %s", endpoints[i], discardErr)
+				} else {
+					log.Printf("[WARN] istanbul_discard failed on %s due to %s", endpoints[i], discardErr)
+				}
+			}
+		}
+	}()
+	for i, client := range clients {
+		if err := client.CallContext(ctx, nil, "istanbul_propose", address, auth); err != nil {
+			return fmt.Errorf("istanbul_propose failed on %s due to %s", endpoints[i], err)
+		}
+	}
+	err = pollUntil(ctx, interval, func() (bool, error) {
+		for i, client := range clients {
+			ok, err := isIstanbulValidator(ctx, client, address)
+			if err != nil {
+				return false, fmt.Errorf("%s on %s", err, endpoints[i])
+			}
+			if ok != auth {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("proposal for %s is not in effect due to %s", address.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package quorum
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToJSONWebKey_whenUsingThumbprint(t *testing.T) {
+	// example from RFC 7638 section 3.1
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &rsa.PrivateKey{PublicKey: rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}}
+
+	jwk, err := toJSONWebKey(key, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", jwk.Kid)
+	assert.Equal(t, "AQAB", jwk.E)
+}
+
+func TestSignJWT_whenTypical(t *testing.T) {
+	for _, alg := range []string{jwtAlgorithmRS256, jwtAlgorithmES256} {
+		key, err := generateSigningKey(alg, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyPEM, err := encodeSigningKeyPEM(key)
+		assert.NoError(t, err)
+		decodedKey, err := decodeSigningKeyPEM(keyPEM)
+		assert.NoError(t, err)
+
+		token, err := signJWT(decodedKey, "kid-1", map[string]interface{}{"sub": "test"})
+		assert.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		if !assert.Len(t, parts, 3) {
+			continue
+		}
+		var header map[string]string
+		rawHeader, _ := base64.RawURLEncoding.DecodeString(parts[0])
+		assert.NoError(t, json.Unmarshal(rawHeader, &header))
+		assert.Equal(t, alg, header["alg"])
+		assert.Equal(t, "kid-1", header["kid"])
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		assert.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			assert.NoError(t, rsa.VerifyPKCS1v15(&k.PublicKey, crypto.SHA256, digest[:], signature))
+		case *ecdsa.PrivateKey:
+			assert.Len(t, signature, 64)
+			r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+			assert.True(t, ecdsa.Verify(&k.PublicKey, digest[:], r, s))
+		}
+	}
+}
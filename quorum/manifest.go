@@ -0,0 +1,135 @@
+package quorum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const networkManifestFileName = "network.json"
+
+// networkManifest describes a bootstrapped network. It is kept in the internal registry
+// keyed by the network ID so nodes being created in parallel update the same instance
+type networkManifest struct {
+	mux  sync.Mutex
+	file string
+
+	Name        string                   `json:"name"`
+	ChainID     *big.Int                 `json:"chain_id,omitempty"`
+	GenesisHash string                   `json:"genesis_hash,omitempty"`
+	Nodes       map[string]*manifestNode `json:"nodes"`
+}
+
+type manifestNode struct {
+	NodeDir         string            `json:"node_dir"`
+	EnodeURL        string            `json:"enode_url"`
+	HexNodeID       string            `json:"hex_node_id"`
+	IstanbulAddress string            `json:"istanbul_address"`
+	Hostname        string            `json:"hostname"`
+	P2PPort         int               `json:"p2p_port"`
+	RaftPort        int               `json:"raft_port,omitempty"`
+	Accounts        map[string]string `json:"accounts"`
+	TmPublicKey     string            `json:"tm_public_key"`
+}
+
+func newNetworkManifest(networkDir string, name string) *networkManifest {
+	return &networkManifest{
+		file:  filepath.Join(networkDir, networkManifestFileName),
+		Name:  name,
+		Nodes: make(map[string]*manifestNode),
+	}
+}
+
+// lookup the manifest of the network located at networkDir. It is loaded from the file if not yet in the registry.
+// Returns nil if the directory is not a network created by quorum_bootstrap_network
+func lookupNetworkManifest(config *configurer, networkDir string) (*networkManifest, error) {
+	raw, err := config.registry.getOrLoad(networkDir, func() (interface{}, error) {
+		m := newNetworkManifest(networkDir, "")
+		data, err := ioutil.ReadFile(m.file)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("can't parse %s due to %s", m.file, err)
+		}
+		if m.Nodes == nil {
+			m.Nodes = make(map[string]*manifestNode)
+		}
+		return m, nil
+	})
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	return raw.(*networkManifest), nil
+}
+
+// add or replace the node and write the manifest file.
+// All nodes in a network must share the same genesis
+func (m *networkManifest) putNode(name string, n *manifestNode, chainID *big.Int, genesisHash string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if len(m.Nodes) > 0 && m.GenesisHash != "" && m.GenesisHash != genesisHash {
+		return fmt.Errorf("genesis hash %s is different from %s of other nodes in the network", genesisHash, m.GenesisHash)
+	}
+	m.ChainID, m.GenesisHash = chainID, genesisHash
+	m.Nodes[name] = n
+	return m.save()
+}
+
+// set the network name and write the manifest file
+func (m *networkManifest) rename(name string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	m.Name = name
+	return m.save()
+}
+
+// replace accounts of the node and write the manifest file. It is a no-op if the node is not listed
+func (m *networkManifest) setNodeAccounts(name string, accounts map[string]string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	n, ok := m.Nodes[name]
+	if !ok {
+		return nil
+	}
+	n.Accounts = accounts
+	return m.save()
+}
+
+// convert accounts of quorum_bootstrap_node into manifest accounts keyed by name
+func toManifestAccounts(rawAccounts []interface{}) map[string]string {
+	accounts := make(map[string]string)
+	for name, address := range toAccountsByName(rawAccounts) {
+		accounts[name] = address.(string)
+	}
+	return accounts
+}
+
+// remove the node and write the manifest file
+func (m *networkManifest) removeNode(name string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	delete(m.Nodes, name)
+	if len(m.Nodes) == 0 {
+		m.ChainID, m.GenesisHash = nil, ""
+	}
+	return m.save()
+}
+
+func (m *networkManifest) save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(m.file, data, 0644); err != nil {
+		return fmt.Errorf("can't write %s due to %s", m.file, err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package quorum
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupNetworkManifest_whenLoadingFromFile(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "manifest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempdir)
+	saved := newNetworkManifest(tempdir, "test-network")
+	assert.NoError(t, saved.putNode("node-0", &manifestNode{EnodeURL: "enode://a@127.0.0.1:21000"}, big.NewInt(10), "0x01"))
+
+	loaded, err := lookupNetworkManifest(&configurer{registry: newInternalRegistry()}, tempdir)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test-network", loaded.Name)
+	assert.Equal(t, big.NewInt(10), loaded.ChainID)
+	assert.Equal(t, "enode://a@127.0.0.1:21000", loaded.Nodes["node-0"].EnodeURL)
+	assert.EqualError(t, loaded.putNode("node-1", &manifestNode{}, big.NewInt(10), "0x02"), "genesis hash 0x02 is different from 0x01 of other nodes in the network")
+}
+
+func TestLookupNetworkManifest_whenNotANetwork(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "manifest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempdir)
+
+	m, err := lookupNetworkManifest(&configurer{registry: newInternalRegistry()}, tempdir)
+
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+}
@@ -0,0 +1,175 @@
+package quorum
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	pbind "github.com/ethereum/go-ethereum/permission/bind"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// permissionDeployer is the sender used to deploy permission contracts in the simulated EVM.
+// Nobody holds its private key so deployed addresses can't collide with future contract creations.
+var permissionDeployer = common.BytesToAddress(crypto.Keccak256([]byte("quorum_bootstrap_permissions")))
+
+// permissionChainConfig enables all forks which permission contracts compiled by solc 0.5 rely on
+var permissionChainConfig = &params.ChainConfig{
+	ChainID:             big.NewInt(1),
+	HomesteadBlock:      new(big.Int),
+	EIP150Block:         new(big.Int),
+	EIP155Block:         new(big.Int),
+	EIP158Block:         new(big.Int),
+	ByzantiumBlock:      new(big.Int),
+	ConstantinopleBlock: new(big.Int),
+	// permission contracts exceed the default limit, code size isn't checked for genesis alloc
+	MaxCodeSize: 128,
+}
+
+type permissionBootstrapInput struct {
+	guardian      common.Address
+	accounts      []common.Address
+	enodes        []string
+	nwAdminOrg    string
+	nwAdminRole   string
+	orgAdminRole  string
+	subOrgBreadth int64
+	subOrgDepth   int64
+}
+
+// permissionBootstrap deploys the permission contracts embedded in the Quorum dependency into an in-memory EVM,
+// performs the network boot sequence which a node would otherwise do on first start
+// and returns the matching permission config and genesis alloc
+func permissionBootstrap(in *permissionBootstrapInput) (*types.PermissionConfig, core.GenesisAlloc, error) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(ethdb.NewMemDatabase()))
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := &runtime.Config{
+		ChainConfig: permissionChainConfig,
+		BlockNumber: new(big.Int),
+		Time:        new(big.Int),
+		GasLimit:    params.GenesisGasLimit * 1000,
+		State:       statedb,
+	}
+	s := &permissionSimulator{cfg: cfg}
+	pc := &types.PermissionConfig{
+		NwAdminOrg:    in.nwAdminOrg,
+		NwAdminRole:   in.nwAdminRole,
+		OrgAdminRole:  in.orgAdminRole,
+		Accounts:      in.accounts,
+		SubOrgBreadth: big.NewInt(in.subOrgBreadth),
+		SubOrgDepth:   big.NewInt(in.subOrgDepth),
+	}
+	pc.UpgrdAddress = s.deploy(pbind.PermUpgrABI, pbind.PermUpgrBin, in.guardian)
+	pc.OrgAddress = s.deploy(pbind.OrgManagerABI, pbind.OrgManagerBin, pc.UpgrdAddress)
+	pc.RoleAddress = s.deploy(pbind.RoleManagerABI, pbind.RoleManagerBin, pc.UpgrdAddress)
+	pc.AccountAddress = s.deploy(pbind.AcctManagerABI, pbind.AcctManagerBin, pc.UpgrdAddress)
+	pc.VoterAddress = s.deploy(pbind.VoterManagerABI, pbind.VoterManagerBin, pc.UpgrdAddress)
+	pc.NodeAddress = s.deploy(pbind.NodeManagerABI, pbind.NodeManagerBin, pc.UpgrdAddress)
+	pc.InterfAddress = s.deploy(pbind.PermInterfaceABI, pbind.PermInterfaceBin, pc.UpgrdAddress)
+	pc.ImplAddress = s.deploy(pbind.PermImplABI, pbind.PermImplBin, pc.UpgrdAddress, pc.OrgAddress, pc.RoleAddress, pc.AccountAddress, pc.VoterAddress, pc.NodeAddress)
+	s.call(in.guardian, pc.UpgrdAddress, pbind.PermUpgrABI, "init", pc.InterfAddress, pc.ImplAddress)
+	s.call(permissionDeployer, pc.InterfAddress, pbind.PermInterfaceABI, "setPolicy", in.nwAdminOrg, in.nwAdminRole, in.orgAdminRole)
+	s.call(permissionDeployer, pc.InterfAddress, pbind.PermInterfaceABI, "init", pc.SubOrgBreadth, pc.SubOrgDepth)
+	for _, e := range in.enodes {
+		s.call(permissionDeployer, pc.InterfAddress, pbind.PermInterfaceABI, "addAdminNode", e)
+	}
+	for _, a := range in.accounts {
+		s.call(permissionDeployer, pc.InterfAddress, pbind.PermInterfaceABI, "addAdminAccount", a)
+	}
+	s.call(permissionDeployer, pc.InterfAddress, pbind.PermInterfaceABI, "updateNetworkBootStatus")
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	alloc := make(core.GenesisAlloc)
+	for _, addr := range []common.Address{pc.UpgrdAddress, pc.OrgAddress, pc.RoleAddress, pc.AccountAddress, pc.VoterAddress, pc.NodeAddress, pc.InterfAddress, pc.ImplAddress} {
+		acc, err := dumpGenesisAccount(statedb, addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		alloc[addr] = *acc
+	}
+	return pc, alloc, nil
+}
+
+type permissionSimulator struct {
+	cfg *runtime.Config
+	err error
+}
+
+func (s *permissionSimulator) deploy(abiJSON string, bin string, args ...interface{}) common.Address {
+	if s.err != nil {
+		return common.Address{}
+	}
+	input, err := packPermissionInput(abiJSON, "", args...)
+	if err != nil {
+		s.err = err
+		return common.Address{}
+	}
+	s.cfg.Origin = permissionDeployer
+	_, addr, _, err := runtime.Create(append(common.FromHex(bin), input...), s.cfg)
+	if err != nil {
+		s.err = fmt.Errorf("can't deploy permission contract due to %s", err)
+	}
+	return addr
+}
+
+func (s *permissionSimulator) call(from common.Address, to common.Address, abiJSON string, method string, args ...interface{}) {
+	if s.err != nil {
+		return
+	}
+	input, err := packPermissionInput(abiJSON, method, args...)
+	if err != nil {
+		s.err = err
+		return
+	}
+	s.cfg.Origin = from
+	if _, _, err := runtime.Call(to, input, s.cfg); err != nil {
+		s.err = fmt.Errorf("can't execute %s on permission contract %s due to %s", method, strings.ToLower(to.Hex()), err)
+	}
+}
+
+func packPermissionInput(abiJSON string, method string, args ...interface{}) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack(method, args...)
+}
+
+func dumpGenesisAccount(statedb *state.StateDB, addr common.Address) (*core.GenesisAccount, error) {
+	acc := &core.GenesisAccount{
+		Code:    statedb.GetCode(addr),
+		Balance: statedb.GetBalance(addr),
+		Nonce:   statedb.GetNonce(addr),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+	st := statedb.StorageTrie(addr)
+	if st == nil {
+		return acc, nil
+	}
+	it := trie.NewIterator(st.NodeIterator(nil))
+	for it.Next() {
+		_, content, _, err := rlp.Split(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		key := st.GetKey(it.Key)
+		if key == nil {
+			return nil, fmt.Errorf("can't find storage key preimage for %s", strings.ToLower(addr.Hex()))
+		}
+		acc.Storage[common.BytesToHash(key)] = common.BytesToHash(content)
+	}
+	return acc, it.Err
+}
@@ -0,0 +1,209 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// actions of quorumPermission_updateOrgStatus, quorumPermission_updateNodeStatus and quorumPermission_updateAccountStatus
+const (
+	permissionActionSuspendOrg      = 1
+	permissionActionActivateOrg     = 2
+	permissionActionDeactivateNode  = 1
+	permissionActionActivateNode    = 2
+	permissionActionSuspendAccount  = 1
+	permissionActionActivateAccount = 2
+)
+
+// permissionOrgRevokeSuspension is the status of a suspended org whose reactivation is pending approval.
+// It is reported by the permission contracts but not defined in types.OrgStatus of the bundled Quorum
+const permissionOrgRevokeSuspension types.OrgStatus = 5
+
+// names of types.OrgStatus, types.NodeStatus and types.AcctStatus which start from 1
+var (
+	permissionOrgStatuses     = []string{"PendingApproval", "Approved", "PendingSuspension", "Suspended", "RevokeSuspension"}
+	permissionNodeStatuses    = []string{"PendingApproval", "Approved", "Deactivated", "Blacklisted", "RecoveryInitiated"}
+	permissionAccountStatuses = []string{"PendingApproval", "Active", "Inactive", "Suspended", "Blacklisted", "Revoked", "RecoveryInitiated", "RecoveryCompleted"}
+)
+
+func permissionStatusName(names []string, status uint8) string {
+	if status == 0 || int(status) > len(names) {
+		return fmt.Sprintf("Unknown(%d)", status)
+	}
+	return names[status-1]
+}
+
+// permissionTxArgs is the transaction argument of quorumPermission APIs
+type permissionTxArgs struct {
+	From common.Address `json:"from"`
+}
+
+// callPermission invokes a quorumPermission API which sends a transaction to the permission contracts
+// from the account. The account must be unlocked in the node
+func callPermission(ctx context.Context, client *rpc.Client, method string, from common.Address, args ...interface{}) error {
+	var msg string
+	if err := client.CallContext(ctx, &msg, "quorumPermission_"+method, append(args, &permissionTxArgs{From: from})...); err != nil {
+		return fmt.Errorf("quorumPermission_%s failed due to %s", method, err)
+	}
+	log.Println("[DEBUG] quorumPermission_"+method, msg)
+	return nil
+}
+
+// lookupPermissionOrg returns the org by its full ID or nil if the org does not exist
+func lookupPermissionOrg(ctx context.Context, client *rpc.Client, fullOrgID string) (*types.OrgInfo, error) {
+	var orgs []*types.OrgInfo
+	if err := client.CallContext(ctx, &orgs, "quorumPermission_orgList"); err != nil {
+		return nil, fmt.Errorf("quorumPermission_orgList failed due to %s", err)
+	}
+	for _, o := range orgs {
+		if o.FullOrgId == fullOrgID {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+// lookupPermissionNode returns the node by its hex node ID or nil if the node does not exist
+func lookupPermissionNode(ctx context.Context, client *rpc.Client, hexNodeID string) (*types.NodeInfo, error) {
+	var nodes []*types.NodeInfo
+	if err := client.CallContext(ctx, &nodes, "quorumPermission_nodeList"); err != nil {
+		return nil, fmt.Errorf("quorumPermission_nodeList failed due to %s", err)
+	}
+	for _, n := range nodes {
+		if _, id, err := parseEnodeURL(n.Url); err == nil && strings.EqualFold(id, hexNodeID) {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+// lookupPermissionAccount returns the account or nil if the account does not exist
+func lookupPermissionAccount(ctx context.Context, client *rpc.Client, account common.Address) (*types.AccountInfo, error) {
+	var accounts []*types.AccountInfo
+	if err := client.CallContext(ctx, &accounts, "quorumPermission_acctList"); err != nil {
+		return nil, fmt.Errorf("quorumPermission_acctList failed due to %s", err)
+	}
+	for _, a := range accounts {
+		if a.AcctId == account {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+// callPermissionApprover invokes a quorumPermission API from an approver via its own endpoint
+// which defaults to the resource rpc_endpoint
+func callPermissionApprover(ctx context.Context, config *configurer, d *schema.ResourceData, approver map[string]interface{}, method string, args ...interface{}) error {
+	endpoint := approver["rpc_endpoint"].(string)
+	if endpoint == "" {
+		endpoint = d.Get("rpc_endpoint").(string)
+	}
+	client, err := config.dialRPC(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return callPermission(ctx, client, method, common.HexToAddress(approver["from"].(string)), args...)
+}
+
+// approvePermissionOrg invokes an approval API of an org from each approver of the resource
+func approvePermissionOrg(ctx context.Context, config *configurer, d *schema.ResourceData, method string, args ...interface{}) diag.Diagnostics {
+	for i, raw := range d.Get("approver").([]interface{}) {
+		approver := raw.(map[string]interface{})
+		if err := callPermissionApprover(ctx, config, d, approver, method, args...); err != nil {
+			return attributeDiag(cty.GetAttrPath("approver").IndexInt(i), err)
+		}
+	}
+	return nil
+}
+
+// waitForPermissionOrg polls until the org is in one of the statuses
+func waitForPermissionOrg(ctx context.Context, client *rpc.Client, fullOrgID string, statuses ...types.OrgStatus) (*types.OrgInfo, error) {
+	var org *types.OrgInfo
+	err := pollUntil(ctx, defaultPollInterval, func() (bool, error) {
+		var err error
+		org, err = lookupPermissionOrg(ctx, client, fullOrgID)
+		if err != nil || org == nil {
+			return false, err
+		}
+		for _, s := range statuses {
+			if org.Status == s {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("org %s is not in the expected status due to %s", fullOrgID, err)
+	}
+	return org, nil
+}
+
+// waitForPermissionNode polls until the node is in one of the statuses
+func waitForPermissionNode(ctx context.Context, client *rpc.Client, hexNodeID string, statuses ...types.NodeStatus) (*types.NodeInfo, error) {
+	var node *types.NodeInfo
+	err := pollUntil(ctx, defaultPollInterval, func() (bool, error) {
+		var err error
+		node, err = lookupPermissionNode(ctx, client, hexNodeID)
+		if err != nil || node == nil {
+			return false, err
+		}
+		for _, s := range statuses {
+			if node.Status == s {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("node %s is not in the expected status due to %s", hexNodeID, err)
+	}
+	return node, nil
+}
+
+// waitForPermissionAccount polls until the account is in one of the statuses with the role. Any role is accepted if roleID is empty
+func waitForPermissionAccount(ctx context.Context, client *rpc.Client, account common.Address, roleID string, statuses ...types.AcctStatus) (*types.AccountInfo, error) {
+	var acct *types.AccountInfo
+	err := pollUntil(ctx, defaultPollInterval, func() (bool, error) {
+		var err error
+		acct, err = lookupPermissionAccount(ctx, client, account)
+		if err != nil || acct == nil || (roleID != "" && acct.RoleId != roleID) {
+			return false, err
+		}
+		for _, s := range statuses {
+			if acct.Status == s {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("account %s is not in the expected status due to %s", account.Hex(), err)
+	}
+	return acct, nil
+}
+
+func setPermissionOrg(d *schema.ResourceData, org *types.OrgInfo) {
+	_ = d.Set("full_org_id", org.FullOrgId)
+	_ = d.Set("status", permissionStatusName(permissionOrgStatuses, uint8(org.Status)))
+	level := 0
+	if org.Level != nil {
+		level = int(org.Level.Int64())
+	}
+	_ = d.Set("level", level)
+}
+
+func setPermissionAccount(d *schema.ResourceData, acct *types.AccountInfo) {
+	_ = d.Set("role_id", acct.RoleId)
+	_ = d.Set("status", permissionStatusName(permissionAccountStatuses, uint8(acct.Status)))
+	_ = d.Set("is_org_admin", acct.IsOrgAdmin)
+}
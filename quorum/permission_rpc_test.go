@@ -0,0 +1,366 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPermissionAdminOrg = "ADMINORG"
+
+var (
+	testPermissionNetworkAdmins = []common.Address{
+		common.HexToAddress("0xed9d02e382b34818e88b88a309c7fe71e65f419d"),
+		common.HexToAddress("0xca843569e3427144cead5e4d5999a3d0ccf92b8e"),
+	}
+	testPermissionAccount = common.HexToAddress("0x0fbdc686b912d7722dc86510934589e0aaf3b55a")
+)
+
+// PermissionTxArgsStub is the transaction argument of PermissionAPIStub
+type PermissionTxArgsStub struct {
+	From common.Address `json:"from"`
+}
+
+// PermissionAPIStub stands in for quorumPermission RPC API where every transaction is mined instantly.
+// Approvals need majority of network admins
+type PermissionAPIStub struct {
+	mux      sync.Mutex
+	orgs     map[string]*types.OrgInfo
+	nodes    []*types.NodeInfo
+	accounts map[common.Address]*types.AccountInfo
+	votes    map[string]map[common.Address]bool
+}
+
+func (api *PermissionAPIStub) OrgList() []*types.OrgInfo {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	orgs := make([]*types.OrgInfo, 0, len(api.orgs))
+	for _, o := range api.orgs {
+		orgs = append(orgs, o)
+	}
+	return orgs
+}
+
+func (api *PermissionAPIStub) NodeList() []*types.NodeInfo {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	return api.nodes
+}
+
+func (api *PermissionAPIStub) AcctList() []*types.AccountInfo {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	accounts := make([]*types.AccountInfo, 0, len(api.accounts))
+	for _, a := range api.accounts {
+		accounts = append(accounts, a)
+	}
+	return accounts
+}
+
+func (api *PermissionAPIStub) AddOrg(orgID string, url string, acct common.Address, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isNetworkAdmin(txa.From) {
+		return "", fmt.Errorf("account is not a network admin")
+	}
+	if _, ok := api.orgs[orgID]; ok {
+		return "", fmt.Errorf("org exists")
+	}
+	api.orgs[orgID] = &types.OrgInfo{OrgId: orgID, FullOrgId: orgID, UltimateParent: orgID, Level: big.NewInt(1), Status: types.OrgPendingApproval}
+	api.nodes = append(api.nodes, &types.NodeInfo{OrgId: orgID, Url: url, Status: types.NodePendingApproval})
+	api.accounts[acct] = &types.AccountInfo{OrgId: orgID, RoleId: "ORGADMIN", AcctId: acct, IsOrgAdmin: true, Status: types.AcctPendingApproval}
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) ApproveOrg(orgID string, _ string, _ common.Address, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	org, err := api.vote(orgID, types.OrgPendingApproval, txa.From)
+	if err != nil || org == nil {
+		return "Action completed successfully", err
+	}
+	org.Status = types.OrgApproved
+	for _, n := range api.nodes {
+		if n.OrgId == orgID {
+			n.Status = types.NodeApproved
+		}
+	}
+	for _, a := range api.accounts {
+		if a.OrgId == orgID {
+			a.Status = types.AcctActive
+		}
+	}
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) AddSubOrg(parentOrgID string, orgID string, url string, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	parent, ok := api.orgs[parentOrgID]
+	if !ok || parent.Status != types.OrgApproved {
+		return "", fmt.Errorf("org does not exist or is not approved")
+	}
+	if !api.isOrgAdmin(txa.From, parentOrgID) {
+		return "", fmt.Errorf("account is not org admin of the org")
+	}
+	fullOrgID := parentOrgID + "." + orgID
+	if _, ok := api.orgs[fullOrgID]; ok {
+		return "", fmt.Errorf("org exists")
+	}
+	api.orgs[fullOrgID] = &types.OrgInfo{OrgId: orgID, FullOrgId: fullOrgID, ParentOrgId: parentOrgID, UltimateParent: parent.UltimateParent, Level: new(big.Int).Add(parent.Level, big.NewInt(1)), Status: types.OrgApproved}
+	if url != "" {
+		api.nodes = append(api.nodes, &types.NodeInfo{OrgId: fullOrgID, Url: url, Status: types.NodeApproved})
+	}
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) UpdateOrgStatus(orgID string, status uint8, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isNetworkAdmin(txa.From) {
+		return "", fmt.Errorf("account is not a network admin")
+	}
+	org, ok := api.orgs[orgID]
+	switch {
+	case ok && status == permissionActionSuspendOrg && org.Status == types.OrgApproved:
+		org.Status = types.OrgPendingSuspension
+	case ok && status == permissionActionActivateOrg && org.Status == types.OrgSuspended:
+		org.Status = permissionOrgRevokeSuspension
+	default:
+		return "", fmt.Errorf("operation cannot be performed")
+	}
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) ApproveOrgStatus(orgID string, status uint8, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	pending, approved := types.OrgPendingSuspension, types.OrgSuspended
+	switch status {
+	case permissionActionSuspendOrg:
+	case permissionActionActivateOrg:
+		pending, approved = permissionOrgRevokeSuspension, types.OrgApproved
+	default:
+		return "", fmt.Errorf("operation cannot be performed")
+	}
+	org, err := api.vote(orgID, pending, txa.From)
+	if err != nil || org == nil {
+		return "Action completed successfully", err
+	}
+	org.Status = approved
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) AddNode(orgID string, url string, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isOrgAdmin(txa.From, orgID) {
+		return "", fmt.Errorf("account is not org admin of the org")
+	}
+	for _, n := range api.nodes {
+		if n.Url == url {
+			return "", fmt.Errorf("node already exists")
+		}
+	}
+	api.nodes = append(api.nodes, &types.NodeInfo{OrgId: orgID, Url: url, Status: types.NodeApproved})
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) UpdateNodeStatus(orgID string, url string, action uint8, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isOrgAdmin(txa.From, orgID) {
+		return "", fmt.Errorf("account is not org admin of the org")
+	}
+	for _, n := range api.nodes {
+		if n.OrgId != orgID || n.Url != url {
+			continue
+		}
+		switch {
+		case action == permissionActionDeactivateNode && n.Status == types.NodeApproved:
+			n.Status = types.NodeDeactivated
+			return "Action completed successfully", nil
+		case action == permissionActionActivateNode && n.Status == types.NodeDeactivated:
+			n.Status = types.NodeApproved
+			return "Action completed successfully", nil
+		}
+	}
+	return "", fmt.Errorf("operation cannot be performed")
+}
+
+func (api *PermissionAPIStub) AddAccountToOrg(acct common.Address, orgID string, roleID string, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isOrgAdmin(txa.From, orgID) {
+		return "", fmt.Errorf("account is not org admin of the org")
+	}
+	if _, ok := api.accounts[acct]; ok {
+		return "", fmt.Errorf("account already exists")
+	}
+	api.accounts[acct] = &types.AccountInfo{OrgId: orgID, RoleId: roleID, AcctId: acct, Status: types.AcctActive}
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) UpdateAccountStatus(orgID string, acct common.Address, status uint8, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isOrgAdmin(txa.From, orgID) {
+		return "", fmt.Errorf("account is not org admin of the org")
+	}
+	a, ok := api.accounts[acct]
+	switch {
+	case ok && a.OrgId == orgID && status == permissionActionSuspendAccount && a.Status == types.AcctActive:
+		a.Status = types.AcctSuspended
+	case ok && a.OrgId == orgID && status == permissionActionActivateAccount && a.Status == types.AcctSuspended:
+		a.Status = types.AcctActive
+	default:
+		return "", fmt.Errorf("operation cannot be performed")
+	}
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) ChangeAccountRole(acct common.Address, orgID string, roleID string, txa PermissionTxArgsStub) (string, error) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	if !api.isOrgAdmin(txa.From, orgID) {
+		return "", fmt.Errorf("account is not org admin of the org")
+	}
+	a, ok := api.accounts[acct]
+	if !ok || a.OrgId != orgID || a.Status != types.AcctActive {
+		return "", fmt.Errorf("operation cannot be performed")
+	}
+	a.RoleId = roleID
+	return "Action completed successfully", nil
+}
+
+func (api *PermissionAPIStub) isNetworkAdmin(from common.Address) bool {
+	a, ok := api.accounts[from]
+	return ok && a.OrgId == testPermissionAdminOrg && a.IsOrgAdmin && a.Status == types.AcctActive
+}
+
+func (api *PermissionAPIStub) isOrgAdmin(from common.Address, orgID string) bool {
+	a, ok := api.accounts[from]
+	return ok && a.IsOrgAdmin && a.Status == types.AcctActive && (a.OrgId == orgID || strings.HasPrefix(orgID, a.OrgId+"."))
+}
+
+// vote records the vote of a network admin and returns the org once majority is reached
+func (api *PermissionAPIStub) vote(orgID string, status types.OrgStatus, from common.Address) (*types.OrgInfo, error) {
+	if !api.isNetworkAdmin(from) {
+		return nil, fmt.Errorf("account is not a network admin")
+	}
+	org, ok := api.orgs[orgID]
+	if !ok || org.Status != status {
+		return nil, fmt.Errorf("nothing to approve")
+	}
+	key := fmt.Sprintf("%s/%d", orgID, status)
+	if api.votes[key] == nil {
+		api.votes[key] = make(map[common.Address]bool)
+	}
+	api.votes[key][from] = true
+	if len(api.votes[key]) <= len(testPermissionNetworkAdmins)/2 {
+		return nil, nil
+	}
+	delete(api.votes, key)
+	return org, nil
+}
+
+func (api *PermissionAPIStub) org(fullOrgID string) *types.OrgInfo {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	return api.orgs[fullOrgID]
+}
+
+func (api *PermissionAPIStub) node(hexNodeID string) *types.NodeInfo {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	for _, n := range api.nodes {
+		if strings.Contains(n.Url, hexNodeID) {
+			return n
+		}
+	}
+	return nil
+}
+
+func (api *PermissionAPIStub) account(acct common.Address) *types.AccountInfo {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	return api.accounts[acct]
+}
+
+// start a stub permissioned network whose network admin org is approved and return its RPC endpoint
+func newTestPermissionNetwork(t *testing.T) (*PermissionAPIStub, string) {
+	api := &PermissionAPIStub{
+		orgs: map[string]*types.OrgInfo{
+			testPermissionAdminOrg: {OrgId: testPermissionAdminOrg, FullOrgId: testPermissionAdminOrg, UltimateParent: testPermissionAdminOrg, Level: big.NewInt(1), Status: types.OrgApproved},
+		},
+		nodes: []*types.NodeInfo{
+			{OrgId: testPermissionAdminOrg, Url: "enode://f06c06f1d958cb2edf90d8bfb912de287f9b047b4228436e94b5b78e3ee16171d0ba2d2f9f8ab0d4d8e4a1fe07d2d43c0d66e2d3e04a61e2e54ce1b4f5ea2d8f@10.0.0.1:21000?discport=0", Status: types.NodeApproved},
+		},
+		accounts: make(map[common.Address]*types.AccountInfo),
+		votes:    make(map[string]map[common.Address]bool),
+	}
+	for _, a := range testPermissionNetworkAdmins {
+		api.accounts[a] = &types.AccountInfo{OrgId: testPermissionAdminOrg, RoleId: "ADMIN", AcctId: a, IsOrgAdmin: true, Status: types.AcctActive}
+	}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("quorumPermission", api); err != nil {
+		t.Fatal(err)
+	}
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+	return api, httpSrv.URL
+}
+
+func TestPermissionStatusName(t *testing.T) {
+	assert.Equal(t, "PendingApproval", permissionStatusName(permissionOrgStatuses, uint8(types.OrgPendingApproval)))
+	assert.Equal(t, "Suspended", permissionStatusName(permissionOrgStatuses, uint8(types.OrgSuspended)))
+	assert.Equal(t, "Deactivated", permissionStatusName(permissionNodeStatuses, uint8(types.NodeDeactivated)))
+	assert.Equal(t, "Active", permissionStatusName(permissionAccountStatuses, uint8(types.AcctActive)))
+	assert.Equal(t, "Unknown(9)", permissionStatusName(permissionAccountStatuses, 9))
+}
+
+func TestLookupPermissionNode(t *testing.T) {
+	_, endpoint := newTestPermissionNetwork(t)
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	node, err := lookupPermissionNode(context.Background(), client, "F06C06F1D958CB2EDF90D8BFB912DE287F9B047B4228436E94B5B78E3EE16171D0BA2D2F9F8AB0D4D8E4A1FE07D2D43C0D66E2D3E04A61E2E54CE1B4F5EA2D8F")
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, node) {
+		assert.Equal(t, testPermissionAdminOrg, node.OrgId)
+	}
+
+	node, err = lookupPermissionNode(context.Background(), client, testRaftNodeID)
+
+	assert.NoError(t, err)
+	assert.Nil(t, node)
+}
+
+func TestCallPermission_whenRejected(t *testing.T) {
+	_, endpoint := newTestPermissionNetwork(t)
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	err = callPermission(context.Background(), client, "addNode", testPermissionAccount, testPermissionAdminOrg, "enode://"+testRaftNodeID+"@10.0.0.2:21000")
+
+	assert.EqualError(t, err, "quorumPermission_addNode failed due to account is not org admin of the org")
+}
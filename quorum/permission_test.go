@@ -0,0 +1,69 @@
+package quorum
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/ethdb"
+	pbind "github.com/ethereum/go-ethereum/permission/bind"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionBootstrap_whenTypical(t *testing.T) {
+	admin := common.HexToAddress("0xed9d02e382b34818e88b88a309c7fe71e65f419d")
+	other := common.HexToAddress("0xca843569e3427144cead5e4d5999a3d0ccf92b8e")
+	pc, alloc, err := permissionBootstrap(&permissionBootstrapInput{
+		guardian:      admin,
+		accounts:      []common.Address{admin},
+		enodes:        []string{"enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@127.0.0.1:21000?discport=0"},
+		nwAdminOrg:    "ADMINORG",
+		nwAdminRole:   "ADMIN",
+		orgAdminRole:  "ORGADMIN",
+		subOrgBreadth: 4,
+		subOrgDepth:   4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, alloc, 8)
+	for addr, acc := range alloc {
+		assert.NotEmpty(t, acc.Code, "no code for %s", addr.Hex())
+	}
+
+	// replay the alloc as if it were a genesis block
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(ethdb.NewMemDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for addr, acc := range alloc {
+		statedb.SetCode(addr, acc.Code)
+		statedb.SetNonce(addr, acc.Nonce)
+		for k, v := range acc.Storage {
+			statedb.SetState(addr, k, v)
+		}
+	}
+	cfg := &runtime.Config{ChainConfig: permissionChainConfig, State: statedb, Origin: admin}
+	parsed, err := abi.JSON(strings.NewReader(pbind.PermInterfaceABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	callBool := func(method string, args ...interface{}) bool {
+		input, err := parsed.Pack(method, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret, _, err := runtime.Call(pc.InterfAddress, input, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return new(big.Int).SetBytes(ret).Sign() > 0
+	}
+	assert.True(t, callBool("getNetworkBootStatus"))
+	assert.True(t, callBool("isNetworkAdmin", admin))
+	assert.False(t, callBool("isNetworkAdmin", other))
+}
@@ -2,15 +2,23 @@
 package quorum
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	elog "github.com/ethereum/go-ethereum/log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Quorum Provider is used to work with Quorum Network such as creating basic metadata required to bootstrap a new Quorum Network.
@@ -19,32 +27,196 @@ import (
 //
 // Use the navigation to the left to read about the available resources.
 func Provider() *schema.Provider {
-	elog.Root().SetHandler(elog.StreamHandler(os.Stderr, elog.TerminalFormat(false)))
+	elog.Root().SetHandler(elog.FuncHandler(gethLogHandler(elog.StreamHandler(os.Stderr, elog.TerminalFormat(false)))))
 	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"base_dir": {
+				Type:        schema.TypeString,
+				Description: "Directory against which relative `target_dir`, `keystore_dir` and `data_dir` paths are resolved. Default is current working directory. Can also be set via `QUORUM_BASE_DIR` environment variable",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("QUORUM_BASE_DIR", ""),
+			},
+			"default_keystore_kdf": {
+				Type:         schema.TypeString,
+				Description:  "Default strength of the keystore scrypt KDF when `use_light_weight_kdf` is not set. Supported: `standard` and `light`. Default is `standard`",
+				Optional:     true,
+				Default:      "standard",
+				ValidateFunc: validation.StringInSlice([]string{"standard", "light"}, false),
+			},
+			"default_instance_name": {
+				Type:        schema.TypeString,
+				Description: "Default instance name of the node when `instance_name` is not set. Default is `geth`",
+				Optional:    true,
+				Default:     "geth",
+			},
+			"default_argon_options": {
+				Type:        schema.TypeList,
+				Description: "Default Argon2 options used to protect transaction manager private keys",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variant": {
+							Type:         schema.TypeString,
+							Description:  "Algorithm to use when hashing. Allowed values are `id` or `i`",
+							Default:      defaultArgonOpts.Algorithm,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"id", "i"}, false),
+						},
+						"iterations": {
+							Type:        schema.TypeInt,
+							Description: "Number of iterations to cycle through",
+							Default:     defaultArgonOpts.Iterations,
+							Optional:    true,
+						},
+						"memory": {
+							Type:        schema.TypeInt,
+							Description: "Memory limit",
+							Default:     defaultArgonOpts.Memory,
+							Optional:    true,
+						},
+						"parallelism": {
+							Type:        schema.TypeInt,
+							Description: "Number of threads to use",
+							Default:     defaultArgonOpts.Parallelism,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"max_concurrent_kdf": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of concurrent key derivations (scrypt for keystore accounts and Argon2 for transaction manager keys) across all resources. This bounds memory usage when many keys are generated. Default is 2",
+				Optional:     true,
+				Default:      2,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of a running node used by data sources and resources working with a live network, e.g.: `http://localhost:22000`, `ws://localhost:23000` or path to the IPC file. Can also be set via `QUORUM_RPC_ENDPOINT` environment variable",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("QUORUM_RPC_ENDPOINT", ""),
+			},
+			"log_level": {
+				Type:         schema.TypeString,
+				Description:  "Log level of go-ethereum libraries. Supported: `crit`, `error`, `warn`, `info`, `debug` and `trace`. Default is `info`",
+				Optional:     true,
+				Default:      "info",
+				ValidateFunc: validation.StringInSlice([]string{"crit", "error", "warn", "info", "debug", "trace"}, false),
+			},
+		},
 		ResourcesMap: map[string]*schema.Resource{
 			"quorum_bootstrap_account":            resourceBootstrapAccount(),
 			"quorum_bootstrap_data_dir":           resourceBootstrapDataDir(),
+			"quorum_bootstrap_genesis_alloc":      resourceBootstrapGenesisAlloc(),
 			"quorum_bootstrap_istanbul_extradata": resourceBootstrapIstanbulExtradata(),
 			"quorum_bootstrap_keystore":           resourceBootstrapKeyStore(),
 			"quorum_bootstrap_network":            resourceBootstrapNetwork(),
+			"quorum_bootstrap_network_archive":    resourceBootstrapNetworkArchive(),
+			"quorum_bootstrap_node":               resourceBootstrapNode(),
 			"quorum_bootstrap_node_key":           resourceBootstrapNodeKey(),
+			"quorum_bootstrap_permissions":        resourceBootstrapPermissions(),
+			"quorum_bootstrap_secrets_bundle":     resourceBootstrapSecretsBundle(),
+			"quorum_contract":                     resourceContract(),
+			"quorum_istanbul_validator":           resourceIstanbulValidator(),
+			"quorum_permission_account":           resourcePermissionAccount(),
+			"quorum_permission_node":              resourcePermissionNode(),
+			"quorum_permission_org":               resourcePermissionOrg(),
+			"quorum_plugin_settings":              resourcePluginSettings(),
+			"quorum_raft_peer":                    resourceRaftPeer(),
+			"quorum_security_access_token":        resourceSecurityAccessToken(),
+			"quorum_security_plugin_config":       resourceSecurityPluginConfig(),
+			"quorum_security_signing_key":         resourceSecuritySigningKey(),
 			"quorum_transaction_manager_keypair":  resourceTransactionManagerKeyPair(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"quorum_bootstrap_genesis_mixhash": dataSourceBootstrapGenesisMixHash(),
-			"quorum_bootstrap_node_key":        dataSourceBootstrapNodeKey(),
-		},
-		ConfigureFunc: func(_ *schema.ResourceData) (interface{}, error) {
-			return &configurer{
-				registry: newInternalRegistry(),
-			}, nil
+			"quorum_account":                      dataSourceAccount(),
+			"quorum_bootstrap_genesis_mixhash":    dataSourceBootstrapGenesisMixHash(),
+			"quorum_bootstrap_istanbul_extradata": dataSourceBootstrapIstanbulExtradata(),
+			"quorum_bootstrap_node_key":           dataSourceBootstrapNodeKey(),
+			"quorum_node_info":                    dataSourceNodeInfo(),
+			"quorum_tessera_partyinfo":            dataSourceTesseraPartyInfo(),
 		},
+		ConfigureContextFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	lvl, err := elog.LvlFromString(d.Get("log_level").(string))
+	if err != nil {
+		return nil, attributeDiag(cty.GetAttrPath("log_level"), err)
+	}
+	atomic.StoreInt32(&gethLogLevel, int32(lvl))
+	baseDir := d.Get("base_dir").(string)
+	if baseDir != "" {
+		if baseDir, err = filepath.Abs(baseDir); err != nil {
+			return nil, attributeDiag(cty.GetAttrPath("base_dir"), fmt.Errorf("can't obtain absolute path due to %s", err))
+		}
+	}
+	argonOpts := defaultArgonOpts
+	if raw, ok := d.GetOk("default_argon_options"); ok {
+		rawOpts := raw.([]interface{})[0].(map[string]interface{})
+		argonOpts = argonOptions{
+			Algorithm:   rawOpts["variant"].(string),
+			Iterations:  rawOpts["iterations"].(int),
+			Memory:      rawOpts["memory"].(int),
+			Parallelism: rawOpts["parallelism"].(int),
+		}
+	}
+	return &configurer{
+		registry:          newInternalRegistry(),
+		baseDir:           baseDir,
+		useLightWeightKDF: d.Get("default_keystore_kdf").(string) == "light",
+		instanceName:      d.Get("default_instance_name").(string),
+		argonOpts:         argonOpts,
+		kdfSemaphore:      make(chan struct{}, d.Get("max_concurrent_kdf").(int)),
+		rpcEndpoint:       d.Get("rpc_endpoint").(string),
+	}, nil
+}
+
+// go-ethereum logs are filtered by the level configured in the provider
+var gethLogLevel = int32(elog.LvlInfo)
+
+func gethLogHandler(h elog.Handler) func(r *elog.Record) error {
+	return func(r *elog.Record) error {
+		if int32(r.Lvl) > atomic.LoadInt32(&gethLogLevel) {
+			return nil
+		}
+		return h.Log(r)
 	}
 }
 
 type configurer struct {
 	registry            *internalRegistry
 	bootstrapDataDirMux sync.Mutex // make sure we do it one by one otherwise we hit resource temporarily unavailable error
+	baseDir             string
+	useLightWeightKDF   bool
+	instanceName        string
+	argonOpts           argonOptions
+	kdfSemaphore        chan struct{} // bound concurrent memory-hungry key derivations
+	rpcEndpoint         string
+}
+
+// resolve relative path against the configured base directory
+func (c *configurer) resolvePath(p string) string {
+	if c.baseDir == "" || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(c.baseDir, p)
+}
+
+// run the key derivation when a slot is available. The slot is released only when the derivation completes
+// even if the context is cancelled in the meantime as the memory is still being used
+func (c *configurer) runKDF(ctx context.Context, fn func() error) error {
+	select {
+	case c.kdfSemaphore <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return runWithContext(ctx, func() error {
+		defer func() { <-c.kdfSemaphore }()
+		return fn()
+	})
 }
 
 // this is mainly used to reference between resources/data sources
@@ -75,6 +247,22 @@ func (ir *internalRegistry) get(key string) (interface{}, bool) {
 	return v, ok
 }
 
+// get the value or load and save it if missing. Nil value is not saved
+func (ir *internalRegistry) getOrLoad(key string, load func() (interface{}, error)) (interface{}, error) {
+	ir.mux.Lock()
+	defer ir.mux.Unlock()
+
+	if v, ok := ir.registry[key]; ok {
+		return v, nil
+	}
+	v, err := load()
+	if err != nil || v == nil {
+		return nil, err
+	}
+	ir.registry[key] = v
+	return v, nil
+}
+
 func (ir *internalRegistry) delete(id string) {
 	ir.mux.Lock()
 	defer ir.mux.Unlock()
@@ -82,6 +270,9 @@ func (ir *internalRegistry) delete(id string) {
 	delete(ir.registry, id)
 }
 
+// file written into directories created by resources
+const managedDirectoryMarker = ".terraform-provider-quorum"
+
 // create new directory including parents
 func createDirectory(dir string) (string, error) {
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -96,3 +287,153 @@ func createDirectory(dir string) (string, error) {
 	}
 	return absDir, nil
 }
+
+// mark the directory as being created by a resource so it can be safely removed later
+func markManagedDirectory(dir string, resourceType string) error {
+	if err := ioutil.WriteFile(filepath.Join(dir, managedDirectoryMarker), []byte(resourceType+"\n"), 0644); err != nil {
+		return fmt.Errorf("can't write marker file due to %s", err)
+	}
+	return nil
+}
+
+// remove the directory created by a resource. Unless forced, it refuses when the marker file is missing or
+// there are entries other than the expected ones. Expected entries are slash separated path patterns relative to dir,
+// e.g.: `keystore/UTC--*`. An entry matching a pattern is removed with its content, a directory leading to a pattern
+// is inspected recursively and a trailing slash denotes a directory which is expected to be empty.
+// Directories managed by other resources are kept with their parents so they can be destroyed on their own
+func removeManagedDirectory(dir string, forceDestroy bool, expected ...string) error {
+	if dir == "" {
+		return nil
+	}
+	if forceDestroy {
+		return os.RemoveAll(dir)
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, managedDirectoryMarker)); err != nil {
+		return fmt.Errorf("refuse to remove directory [%s] as it was not created by this provider. Set force_destroy to remove it anyway", dir)
+	}
+	unexpected, nested, err := findUnexpectedEntries(dir, "", append(expected, managedDirectoryMarker))
+	if err != nil {
+		return err
+	}
+	if len(unexpected) > 0 {
+		return fmt.Errorf("refuse to remove directory [%s] as it contains unexpected files %v. Set force_destroy to remove it anyway", dir, unexpected)
+	}
+	if len(nested) > 0 {
+		log.Printf("[DEBUG] Keeping directories managed by other resources: dir=%s, nested=%v", dir, nested)
+		return removeEntriesExcept(dir, "", nested)
+	}
+	return os.RemoveAll(dir)
+}
+
+// return entries not matching the expected patterns and directories managed by other resources
+func findUnexpectedEntries(dir string, rel string, expected []string) ([]string, []string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, nil, err
+	}
+	unexpected, nested := make([]string, 0), make([]string, 0)
+	for _, e := range entries {
+		name := path.Join(rel, e.Name())
+		if e.IsDir() && isManagedDirectory(filepath.Join(dir, filepath.FromSlash(name))) {
+			nested = append(nested, name)
+			continue
+		}
+		if matchAny(name, expected) {
+			continue
+		}
+		if e.IsDir() && leadsToAny(name, expected) {
+			u, n, err := findUnexpectedEntries(dir, name, expected)
+			if err != nil {
+				return nil, nil, err
+			}
+			unexpected, nested = append(unexpected, u...), append(nested, n...)
+			continue
+		}
+		unexpected = append(unexpected, name)
+	}
+	return unexpected, nested, nil
+}
+
+func removeEntriesExcept(dir string, rel string, kept []string) error {
+	entries, err := ioutil.ReadDir(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := path.Join(rel, e.Name())
+		isKept, leadsToKept := false, false
+		for _, k := range kept {
+			isKept = isKept || k == name
+			leadsToKept = leadsToKept || strings.HasPrefix(k, name+"/")
+		}
+		if isKept {
+			continue
+		}
+		if leadsToKept {
+			if err := removeEntriesExcept(dir, name, kept); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isManagedDirectory(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, managedDirectoryMarker))
+	return err == nil
+}
+
+func matchAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// true if the directory is an ancestor of entries matching one of the patterns
+func leadsToAny(dir string, patterns []string) bool {
+	depth := strings.Count(dir, "/") + 1
+	for _, p := range patterns {
+		segments := strings.Split(p, "/")
+		if len(segments) <= depth {
+			continue
+		}
+		if ok, _ := path.Match(strings.Join(segments[:depth], "/"), dir); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// convert the error to diagnostics pointing to the offending attribute
+func attributeDiag(path cty.Path, err error) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       err.Error(),
+		AttributePath: path,
+	}}
+}
+
+// run the long operation in background so it can be abandoned when the context is cancelled.
+// The operation itself continues till it completes as it's not interruptible
+func runWithContext(ctx context.Context, fn func() error) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn()
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
@@ -1,14 +1,24 @@
 package quorum
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 )
 
-var testProviders = map[string]terraform.ResourceProvider{
+var testProviders = map[string]*schema.Provider{
 	"quorum": Provider(),
 }
 
@@ -18,3 +28,161 @@ func TestMain(m *testing.M) {
 	}
 	os.Exit(m.Run())
 }
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_stateUpgraders(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if !assert.Len(t, r.StateUpgraders, r.SchemaVersion, "%s: one upgrader is required for each previous schema version", name) {
+			continue
+		}
+		for idx, u := range r.StateUpgraders {
+			assert.Equal(t, idx, u.Version, "%s: upgraders must be ordered by version", name)
+			assert.False(t, u.Type.Equals(cty.NilType), "%s: upgrader for version %d has no type", name, u.Version)
+			assert.NotNil(t, u.Upgrade, "%s: upgrader for version %d has no upgrade function", name, u.Version)
+		}
+	}
+}
+
+func TestRunWithContext_whenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	defer close(done)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	err := runWithContext(ctx, func() error {
+		<-done
+		return nil
+	})
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestRunWithContext_whenCompleted(t *testing.T) {
+	expectedErr := errors.New("test")
+
+	err := runWithContext(context.Background(), func() error {
+		return expectedErr
+	})
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestConfigurerRunKDF_whenBounded(t *testing.T) {
+	c := &configurer{kdfSemaphore: make(chan struct{}, 2)}
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.runKDF(context.Background(), func() error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return nil
+			}))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxRunning)
+}
+
+func TestRemoveManagedDirectory_whenNoMarker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "managed-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = removeManagedDirectory(dir, false)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not created by this provider")
+	assert.DirExists(t, dir)
+}
+
+func TestRemoveManagedDirectory_whenUnexpectedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "managed-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, markManagedDirectory(dir, "test"))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "UTC--key"), []byte{}, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "unrelated"), []byte{}, 0644))
+
+	err = removeManagedDirectory(dir, false, "UTC--*")
+
+	assert.EqualError(t, err, fmt.Sprintf("refuse to remove directory [%s] as it contains unexpected files [unrelated]. Set force_destroy to remove it anyway", dir))
+	assert.NoError(t, removeManagedDirectory(dir, true))
+	assert.NoDirExists(t, dir)
+}
+
+func TestRemoveManagedDirectory_whenNestedManagedDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "managed-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	nested := filepath.Join(dir, "nested")
+	assert.NoError(t, os.Mkdir(nested, 0755))
+	assert.NoError(t, markManagedDirectory(dir, "test"))
+	assert.NoError(t, markManagedDirectory(nested, "test"))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(nested, "UTC--key"), []byte{}, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "UTC--key"), []byte{}, 0644))
+
+	assert.NoError(t, removeManagedDirectory(dir, false, "UTC--*"))
+
+	assert.NoFileExists(t, filepath.Join(dir, "UTC--key"))
+	assert.NoFileExists(t, filepath.Join(dir, managedDirectoryMarker))
+	assert.FileExists(t, filepath.Join(nested, "UTC--key"), "directory managed by another resource is kept")
+	assert.FileExists(t, filepath.Join(nested, managedDirectoryMarker))
+}
+
+func TestRemoveManagedDirectory_whenUnexpectedFilesInExpectedDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "managed-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, markManagedDirectory(dir, "test"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "geth", "chaindata"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "keystore"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "geth", "chaindata", "CURRENT"), []byte{}, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "geth", "nodekey"), []byte{}, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "keystore", "UTC--key"), []byte{}, 0644))
+
+	err = removeManagedDirectory(dir, false, "geth/chaindata", "keystore/")
+
+	assert.EqualError(t, err, fmt.Sprintf("refuse to remove directory [%s] as it contains unexpected files [geth/nodekey keystore/UTC--key]. Set force_destroy to remove it anyway", dir))
+	assert.DirExists(t, dir)
+}
+
+func TestRemoveManagedDirectory_whenOnlyExpectedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "managed-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, markManagedDirectory(dir, "test"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "geth", "chaindata"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "keystore"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "geth", "chaindata", "CURRENT"), []byte{}, 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "keystore", "UTC--key"), []byte{}, 0644))
+
+	assert.NoError(t, removeManagedDirectory(dir, false, "geth/chaindata", "keystore/UTC--*"))
+	assert.NoDirExists(t, dir)
+	assert.NoError(t, removeManagedDirectory(dir, false), "missing directory is considered removed")
+}
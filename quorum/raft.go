@@ -0,0 +1,75 @@
+package quorum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// raftPeer is an entry returned by raft_cluster
+type raftPeer struct {
+	RaftID uint16 `json:"raftId"`
+	NodeID string `json:"nodeId"`
+}
+
+// parseEnodeURL returns the parsed enode URL and its hex node ID
+func parseEnodeURL(enodeURL string) (*url.URL, string, error) {
+	u, err := url.Parse(enodeURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if u.Scheme != "enode" || u.User == nil {
+		return nil, "", fmt.Errorf("invalid enode URL [%s]", enodeURL)
+	}
+	nodeID := strings.ToLower(u.User.Username())
+	if b, err := hex.DecodeString(nodeID); err != nil || len(b) != 64 {
+		return nil, "", fmt.Errorf("node ID [%s] must be 128 hex characters", nodeID)
+	}
+	return u, nodeID, nil
+}
+
+// parseRaftEnodeURL returns the hex node ID of an enode URL used by raft which must contain raftport
+func parseRaftEnodeURL(enodeURL string) (string, error) {
+	u, nodeID, err := parseEnodeURL(enodeURL)
+	if err != nil {
+		return "", err
+	}
+	if _, err := strconv.ParseUint(u.Query().Get("raftport"), 10, 16); err != nil {
+		return "", fmt.Errorf("raftport is missing or invalid in enode URL [%s]", enodeURL)
+	}
+	return nodeID, nil
+}
+
+func validateEnodeURL(i interface{}, s string) (ws []string, es []error) {
+	if _, _, err := parseEnodeURL(i.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s is not a valid enode URL due to %s", s, err))
+	}
+	return
+}
+
+func validateRaftEnodeURL(i interface{}, s string) (ws []string, es []error) {
+	if _, err := parseRaftEnodeURL(i.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s is not a valid raft enode URL due to %s", s, err))
+	}
+	return
+}
+
+// lookupRaftPeer returns the raft ID of the node in the cluster as seen by the node connected by client.
+// Returns 0 if the node is not a member as raft IDs start from 1
+func lookupRaftPeer(ctx context.Context, client *rpc.Client, hexNodeID string) (uint16, error) {
+	var peers []*raftPeer
+	if err := client.CallContext(ctx, &peers, "raft_cluster"); err != nil {
+		return 0, fmt.Errorf("raft_cluster failed due to %s", err)
+	}
+	for _, p := range peers {
+		if strings.EqualFold(strings.TrimPrefix(p.NodeID, "0x"), hexNodeID) {
+			return p.RaftID, nil
+		}
+	}
+	return 0, nil
+}
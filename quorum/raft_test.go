@@ -0,0 +1,36 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRaftNodeID = "ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef"
+
+func TestParseRaftEnodeURL(t *testing.T) {
+	nodeID, err := parseRaftEnodeURL("enode://" + testRaftNodeID + "@127.0.0.1:21000?discport=0&raftport=50400")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testRaftNodeID, nodeID)
+}
+
+func TestParseRaftEnodeURL_whenInvalid(t *testing.T) {
+	for name, enodeURL := range map[string]string{
+		"no raftport":     "enode://" + testRaftNodeID + "@127.0.0.1:21000?discport=0",
+		"invalid node id": "enode://ac6b@127.0.0.1:21000?discport=0&raftport=50400",
+		"not enode":       "http://" + testRaftNodeID + "@127.0.0.1:21000?discport=0&raftport=50400",
+	} {
+		_, err := parseRaftEnodeURL(enodeURL)
+
+		assert.Error(t, err, name)
+	}
+}
+
+func TestParseEnodeURL(t *testing.T) {
+	u, nodeID, err := parseEnodeURL("enode://" + testRaftNodeID + "@127.0.0.1:21000?discport=0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testRaftNodeID, nodeID)
+	assert.Equal(t, "127.0.0.1:21000", u.Host)
+}
@@ -1,23 +1,31 @@
 package quorum
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Use this resource to create a new Ethereum account
 func resourceBootstrapAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBootstrapAccountCreate,
-		Read:   resourceBootstrapAccountRead,
-		Update: resourceBootstrapAccountUpdate,
-		Delete: resourceBootstrapAccountDelete,
+		CreateContext: resourceBootstrapAccountCreate,
+		ReadContext:   resourceBootstrapAccountRead,
+		UpdateContext: resourceBootstrapAccountUpdate,
+		DeleteContext: resourceBootstrapAccountDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"wallet_id": {
@@ -53,12 +61,12 @@ func resourceBootstrapAccount() *schema.Resource {
 	}
 }
 
-func resourceBootstrapAccountCreate(d *schema.ResourceData, raw interface{}) error {
+func resourceBootstrapAccountCreate(ctx context.Context, d *schema.ResourceData, raw interface{}) diag.Diagnostics {
 	registry := raw.(*configurer).registry
 	walletId := d.Get("wallet_id").(string)
 	wallet, ok := registry.get(walletId)
 	if !ok {
-		return fmt.Errorf("wallet %s does not exist nor not registered", walletId)
+		return attributeDiag(cty.GetAttrPath("wallet_id"), fmt.Errorf("wallet %s does not exist nor not registered", walletId))
 	}
 	passphrase := d.Get("passphrase").(string)
 	var newAccount accounts.Account
@@ -66,12 +74,15 @@ func resourceBootstrapAccountCreate(d *schema.ResourceData, raw interface{}) err
 	switch wallet.(type) {
 	case *keystore.KeyStore:
 		ks := wallet.(*keystore.KeyStore)
-		newAccount, err = ks.NewAccount(passphrase)
+		err = raw.(*configurer).runKDF(ctx, func() (err error) {
+			newAccount, err = ks.NewAccount(passphrase)
+			return
+		})
 	default:
 		err = fmt.Errorf("unsupported wallet type: %s", wallet)
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	accountAddress := strings.ToLower(newAccount.Address.String())
 	d.SetId(accountAddress)
@@ -80,17 +91,17 @@ func resourceBootstrapAccountCreate(d *schema.ResourceData, raw interface{}) err
 	return nil
 }
 
-func resourceBootstrapAccountUpdate(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapAccountUpdate(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	// not required to implement due to the `balance` field is merely a place holder
 	// other fields are having `forceNew` is true
 	return nil
 }
 
-func resourceBootstrapAccountRead(_ *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapAccountRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceBootstrapAccountDelete(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapAccountDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	d.SetId("")
 	accountUrl := d.Get("account_url").(string)
 	_ = os.Remove(accountUrl) // it may or may not be a file system path so we ignore the error
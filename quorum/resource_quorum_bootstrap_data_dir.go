@@ -1,56 +1,107 @@
 package quorum
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/node"
 
 	"github.com/ethereum/go-ethereum/core"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	dbChainData      = "chaindata"
+	dbLightChainData = "lightchaindata"
+
+	onExistingFail   = "fail"
+	onExistingVerify = "verify"
+	onExistingReinit = "reinit"
 )
 
 // Use this resource to create a data dir locally. This equivalent to execute `geth init`.
+//
+// `on_existing` decides what happens when the instance directory already has files, e.g. when re-running after a partial failure.
+// `verify` accepts databases whose stored genesis block matches `genesis` and initializes the missing ones.
+// `reinit` removes the databases before initializing them. Other files such as `nodekey` are kept.
+// A data dir whose instance directory or keystore already has files, e.g. one adopted via `on_existing`, is not marked
+// as managed and is only removed on destroy with `force_destroy`. A keystore managed by `quorum_bootstrap_keystore` is kept on destroy.
 func resourceBootstrapDataDir() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBootstrapDataDirCreate,
-		Read:   resourceBootstrapDataDirRead,
-		Delete: resourceBootstrapDataDirDelete,
+		CreateContext: resourceBootstrapDataDirCreate,
+		ReadContext:   resourceBootstrapDataDirRead,
+		UpdateContext: resourceBootstrapDataDirUpdate,
+		DeleteContext: resourceBootstrapDataDirDelete,
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceBootstrapDataDirV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceBootstrapDataDirStateUpgradeV0,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"data_dir": {
 				Type:        schema.TypeString,
-				Description: "Directory to intialize a genesis block",
+				Description: "Directory to intialize a genesis block. Relative path is resolved against provider `base_dir`",
 				Required:    true,
 				ForceNew:    true,
 			},
 			"instance_name": {
 				Type:        schema.TypeString,
-				Description: "The instance name of the node. This must be the same as the value in geth node config. Default is `geth`",
+				Description: "The instance name of the node. This must be the same as the value in geth node config. Default is decided by provider `default_instance_name`",
 				Optional:    true,
 				ForceNew:    true,
-				Default:     "geth",
+				Computed:    true,
 			},
 			"genesis": {
+				Type:         schema.TypeString,
+				Description:  "Genesis file content in JSON format",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateGenesis,
+			},
+			"on_existing": {
+				Type:         schema.TypeString,
+				Description:  "Action when the instance directory is not empty. Supported: fail, verify and reinit. Default is fail",
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{onExistingFail, onExistingVerify, onExistingReinit}, false),
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Description: "True to remove the directory on destroy even if it was not created by this provider or contains unexpected files. It must be applied before destroying",
+				Optional:    true,
+				Default:     false,
+			},
+			"genesis_hash": {
 				Type:        schema.TypeString,
-				Description: "Genesis file content in JSON format",
-				Required:    true,
-				ForceNew:    true,
-				ValidateFunc: func(i interface{}, s string) (ws []string, es []error) {
-					jsonStr := i.(string)
-					var g *core.Genesis
-					if err := json.Unmarshal([]byte(jsonStr), &g); err != nil {
-						es = append(es, err)
-						return
-					}
-					return
-				},
+				Description: "Hash of the genesis block written to or verified against the databases",
+				Computed:    true,
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Description: "True if the data dir was created by this resource. A data dir adopted via `on_existing` or whose keystore was not empty is only removed with `force_destroy`",
+				Computed:    true,
 			},
 			"data_dir_abs": {
 				Type:        schema.TypeString,
@@ -61,58 +112,139 @@ func resourceBootstrapDataDir() *schema.Resource {
 	}
 }
 
-func resourceBootstrapDataDirCreate(d *schema.ResourceData, rawConfigurer interface{}) error {
+func resourceBootstrapDataDirCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
 	config := rawConfigurer.(*configurer)
 	config.bootstrapDataDirMux.Lock()
 	defer config.bootstrapDataDirMux.Unlock()
-	targetDir := d.Get("data_dir").(string)
+	targetDir := config.resolvePath(d.Get("data_dir").(string))
+	if _, ok := d.GetOk("instance_name"); !ok {
+		_ = d.Set("instance_name", config.instanceName)
+	}
+	// only a directory created by this resource is marked as managed, an adopted one requires force_destroy.
+	// Only existing content of the instance directory or of a keystore not created by quorum_bootstrap_keystore counts
+	adopted := false
+	for _, entry := range []string{d.Get("instance_name").(string), "keystore"} {
+		dir := filepath.Join(targetDir, entry)
+		if files, err := ioutil.ReadDir(dir); err == nil && len(files) > 0 && !isManagedDirectory(dir) {
+			adopted = true
+		}
+	}
 	absDir, err := createDirectory(targetDir)
 	if err != nil {
-		return err
+		return attributeDiag(cty.GetAttrPath("data_dir"), err)
+	}
+	databases := []string{dbChainData, dbLightChainData}
+	if _, ok := d.GetOk("on_existing"); !ok {
+		_ = d.Set("on_existing", onExistingFail)
 	}
+	onExisting := d.Get("on_existing").(string)
 	nodeConfig := &node.DefaultConfig
 	nodeConfig.DataDir = absDir
 	nodeConfig.Name = d.Get("instance_name").(string)
 	// check if the target dir is empty
 	if files, err := ioutil.ReadDir(path.Join(absDir, nodeConfig.Name)); err != nil && !os.IsNotExist(err) {
-		return err
-	} else {
-		if len(files) > 0 {
-			return fmt.Errorf("directory [%s] is not empty", absDir)
+		return attributeDiag(cty.GetAttrPath("data_dir"), err)
+	} else if len(files) > 0 {
+		switch onExisting {
+		case onExistingVerify:
+			log.Printf("[DEBUG] Verifying existing data dir: dir=%s", absDir)
+		case onExistingReinit:
+			log.Printf("[DEBUG] Removing existing databases: dir=%s", absDir)
+			for _, name := range databases {
+				if err := os.RemoveAll(path.Join(absDir, nodeConfig.Name, name)); err != nil {
+					return attributeDiag(cty.GetAttrPath("data_dir"), err)
+				}
+			}
+		default:
+			return attributeDiag(cty.GetAttrPath("data_dir"), fmt.Errorf("directory [%s] is not empty, consider setting on_existing to verify or reinit", absDir))
 		}
 	}
 	genesisJson := d.Get("genesis").(string)
 	var genesis *core.Genesis
 	if err := json.Unmarshal([]byte(genesisJson), &genesis); err != nil {
-		return err
+		return attributeDiag(cty.GetAttrPath("genesis"), err)
 	}
-	// init datadir
+	genesisHash, diags := setupGenesis(ctx, nodeConfig, databases, genesis)
+	if diags.HasError() {
+		return diags
+	}
+	if adopted {
+		log.Printf("[WARN] Data dir was not created by this resource, force_destroy is required to remove it: dir=%s", absDir)
+	} else if err := markManagedDirectory(absDir, "quorum_bootstrap_data_dir"); err != nil {
+		return attributeDiag(cty.GetAttrPath("data_dir"), err)
+	}
+	_ = d.Set("managed", !adopted)
+	_ = d.Set("data_dir_abs", absDir)
+	_ = d.Set("genesis_hash", strings.ToLower(genesisHash.Hex()))
+	// nodes of a network share the genesis hash so the instance directory makes the ID unique
+	d.SetId(filepath.Join(absDir, nodeConfig.Name) + "@" + strings.ToLower(genesisHash.Hex()))
+	return nil
+}
+
+func validateGenesis(i interface{}, s string) (ws []string, es []error) {
+	var g *core.Genesis
+	if err := json.Unmarshal([]byte(i.(string)), &g); err != nil {
+		es = append(es, fmt.Errorf("%s: %s", s, err))
+		return
+	}
+	// go-ethereum falls back to the mainnet genesis when it is null and chain config is required to identify the network
+	if g == nil || g.Config == nil || g.Config.ChainID == nil {
+		es = append(es, fmt.Errorf("%s: config.chainId is required", s))
+	}
+	return
+}
+
+func setupGenesis(ctx context.Context, nodeConfig *node.Config, databases []string, genesis *core.Genesis) (common.Hash, diag.Diagnostics) {
+	var genesisHash common.Hash
 	stack, err := node.New(nodeConfig)
 	if err != nil {
-		return err
+		return genesisHash, diag.FromErr(err)
 	}
-	for _, name := range []string{"chaindata", "lightchaindata"} {
+	for _, name := range databases {
 		chaindb, err := stack.OpenDatabase(name, 0, 0)
 		if err != nil {
-			return fmt.Errorf("can't open database for %s due to %s", name, err)
+			return genesisHash, diag.Errorf("can't open database for %s due to %s", name, err)
+		}
+		// when a genesis block is already stored, this only succeeds if it matches
+		err = runWithContext(ctx, func() (err error) {
+			defer chaindb.Close()
+			_, genesisHash, err = core.SetupGenesisBlock(chaindb, genesis)
+			return
+		})
+		if mismatch, ok := err.(*core.GenesisMismatchError); ok {
+			return genesisHash, attributeDiag(cty.GetAttrPath("genesis"), fmt.Errorf("existing %s has a different genesis block %s (expected %s)", name, mismatch.Stored.Hex(), mismatch.New.Hex()))
 		}
-		_, _, err = core.SetupGenesisBlock(chaindb, genesis)
 		if err != nil {
-			return fmt.Errorf("can't setup genesis for %s due to %s", name, err)
+			return genesisHash, diag.Errorf("can't setup genesis for %s due to %s", name, err)
 		}
-		log.Printf("[DEBUG] Successfully wrote genesis state: database=%s, dir=%s", name, absDir)
+		log.Printf("[DEBUG] Successfully wrote genesis state: database=%s, dir=%s", name, nodeConfig.DataDir)
 	}
-	_ = d.Set("data_dir_abs", absDir)
-	d.SetId(fmt.Sprintf("%d", time.Now().UnixNano()))
+	return genesisHash, nil
+}
+
+func dataDirEntries(instanceName string) []string {
+	// node.New creates an empty keystore directory next to the instance directory
+	return []string{path.Join(instanceName, dbChainData), path.Join(instanceName, dbLightChainData), "keystore/"}
+}
+
+func resourceBootstrapDataDirRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceBootstrapDataDirRead(_ *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapDataDirUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only on_existing and force_destroy can be updated. They are used during creation and deletion respectively
 	return nil
 }
 
-func resourceBootstrapDataDirDelete(d *schema.ResourceData, _ interface{}) error {
-	d.SetId("")
+func resourceBootstrapDataDirDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	dir := d.Get("data_dir_abs").(string)
-	return os.RemoveAll(dir)
+	if !d.Get("managed").(bool) && !d.Get("force_destroy").(bool) {
+		return diag.Errorf("refuse to remove directory [%s] as it was not created by this resource. Set force_destroy to remove it anyway", dir)
+	}
+	// keystore directory is created by geth when initializing the data dir
+	if err := removeManagedDirectory(dir, d.Get("force_destroy").(bool), dataDirEntries(d.Get("instance_name").(string))...); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
 }
@@ -0,0 +1,50 @@
+package quorum
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// schema version 0 where there is no `managed` attribute to decide if the directory can be removed
+func resourceBootstrapDataDirV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"data_dir": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"instance_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"genesis": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"data_dir_abs": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// data dirs in version 0 are managed if they carry the marker file written on creation.
+// Without it, `managed` would be false after upgrading and destroy would be refused
+func resourceBootstrapDataDirStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if _, ok := rawState["managed"]; !ok {
+		dir, _ := rawState["data_dir_abs"].(string)
+		rawState["managed"] = dir != "" && isManagedDirectory(dir)
+		log.Printf("[DEBUG] Upgraded data dir state: dir=%s, managed=%t", dir, rawState["managed"])
+	}
+	if _, ok := rawState["force_destroy"]; !ok {
+		rawState["force_destroy"] = false
+	}
+	return rawState, nil
+}
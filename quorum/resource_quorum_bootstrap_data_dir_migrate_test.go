@@ -0,0 +1,74 @@
+package quorum
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBootstrapDataDirStateUpgradeV0(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datadir-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, markManagedDirectory(dir, "quorum_bootstrap_data_dir"))
+	v0 := map[string]interface{}{
+		"data_dir":      dir,
+		"data_dir_abs":  dir,
+		"instance_name": "geth",
+		"genesis":       "{}",
+	}
+	expected := map[string]interface{}{
+		"data_dir":      dir,
+		"data_dir_abs":  dir,
+		"instance_name": "geth",
+		"genesis":       "{}",
+		"managed":       true,
+		"force_destroy": false,
+	}
+
+	actual, err := resourceBootstrapDataDirStateUpgradeV0(context.Background(), v0, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestResourceBootstrapDataDirStateUpgradeV0_whenNoMarker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datadir-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	v0 := map[string]interface{}{
+		"data_dir_abs": dir,
+	}
+
+	actual, err := resourceBootstrapDataDirStateUpgradeV0(context.Background(), v0, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, false, actual["managed"])
+}
+
+func TestResourceBootstrapDataDirStateUpgradeV0_whenManagedKnown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "datadir-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, markManagedDirectory(dir, "quorum_bootstrap_data_dir"))
+	v0 := map[string]interface{}{
+		"data_dir_abs":  dir,
+		"managed":       false,
+		"force_destroy": true,
+	}
+
+	actual, err := resourceBootstrapDataDirStateUpgradeV0(context.Background(), v0, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, false, actual["managed"], "an adopted data dir sharing the directory stays unmanaged")
+	assert.Equal(t, true, actual["force_destroy"])
+}
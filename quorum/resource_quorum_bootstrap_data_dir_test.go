@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // @example
@@ -52,6 +55,207 @@ EOF
                 `, tempdir),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("quorum_bootstrap_data_dir.test", "data_dir_abs"),
+					resource.TestMatchResourceAttr("quorum_bootstrap_data_dir.test", "id", regexp.MustCompile("^/.+/[^/]+@0x[0-9a-f]{64}$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataDirConfig(name string, dataDir string, gasLimit string, onExisting string) string {
+	// an existing data dir is adopted when not failing so it can only be removed with force_destroy
+	return fmt.Sprintf(`
+resource "quorum_bootstrap_data_dir" "%s" {
+  data_dir      = "%s"
+  on_existing   = "%s"
+  force_destroy = %t
+  genesis       = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "%s"
+  })
+}
+`, name, dataDir, onExisting, onExisting != onExistingFail, gasLimit)
+}
+
+func TestAccResourceBootstrapDataDir_whenAdopted(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	if err := os.Mkdir(filepath.Join(tempdir, "keystore"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	userFile := filepath.Join(tempdir, "keystore", "UTC--user")
+	if err := ioutil.WriteFile(userFile, []byte("user"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := func(forceDestroy bool) string {
+		return fmt.Sprintf(`
+resource "quorum_bootstrap_data_dir" "test" {
+  data_dir      = "%s"
+  force_destroy = %t
+  genesis       = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+}
+`, tempdir, forceDestroy)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_data_dir.test", "managed", "false"),
+					func(_ *terraform.State) error {
+						if _, err := os.Stat(filepath.Join(tempdir, managedDirectoryMarker)); !os.IsNotExist(err) {
+							return fmt.Errorf("expect adopted directory not to be marked")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("was not created by this resource"),
+			},
+			{
+				Config: config(true),
+				Check: func(_ *terraform.State) error {
+					_, err := os.Stat(userFile)
+					return err
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapDataDir_whenKeystoreCreatedFirst(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			for _, name := range []string{"geth", "keystore", managedDirectoryMarker} {
+				if _, err := os.Stat(filepath.Join(tempdir, name)); !os.IsNotExist(err) {
+					return fmt.Errorf("expect %s to be removed", name)
+				}
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_bootstrap_keystore" "test" {
+  keystore_dir = "%s/keystore"
+  account {
+    name = "acc0"
+  }
+}
+
+resource "quorum_bootstrap_data_dir" "test" {
+  data_dir   = "%s"
+  genesis    = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+  depends_on = [quorum_bootstrap_keystore.test]
+}
+`, tempdir, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_data_dir.test", "managed", "true"),
+					func(_ *terraform.State) error {
+						_, err := os.Stat(filepath.Join(tempdir, managedDirectoryMarker))
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapDataDir_whenVerifyExisting(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	first := testAccDataDirConfig("first", tempdir, "0xE0000000", "fail")
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: first,
+			},
+			{
+				Config:      first + testAccDataDirConfig("failed", tempdir, "0xE0000000", "fail"),
+				ExpectError: regexp.MustCompile("is not empty"),
+			},
+			{
+				Config: first + testAccDataDirConfig("verified", tempdir, "0xE0000000", "verify"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_data_dir.verified", "genesis_hash", "quorum_bootstrap_data_dir.first", "genesis_hash"),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_data_dir.verified", "id", "quorum_bootstrap_data_dir.first", "id"),
+					resource.TestCheckResourceAttr("quorum_bootstrap_data_dir.first", "managed", "true"),
+					resource.TestCheckResourceAttr("quorum_bootstrap_data_dir.verified", "managed", "false"),
+				),
+			},
+			{
+				Config:      first + testAccDataDirConfig("verified", tempdir, "0xE0000000", "verify") + testAccDataDirConfig("mismatched", tempdir, "0xF0000000", "verify"),
+				ExpectError: regexp.MustCompile("different genesis block"),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapDataDir_whenReinitExisting(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	nodeKeyFile := filepath.Join(tempdir, "geth", "nodekey")
+	first := testAccDataDirConfig("first", tempdir, "0xE0000000", "fail")
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: first,
+				Check: func(_ *terraform.State) error {
+					return ioutil.WriteFile(nodeKeyFile, []byte("nodekey"), 0600)
+				},
+			},
+			{
+				Config: first + testAccDataDirConfig("reinit", tempdir, "0xF0000000", "reinit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(s *terraform.State) error {
+						firstHash := s.RootModule().Resources["quorum_bootstrap_data_dir.first"].Primary.Attributes["genesis_hash"]
+						reinitHash := s.RootModule().Resources["quorum_bootstrap_data_dir.reinit"].Primary.Attributes["genesis_hash"]
+						if firstHash == reinitHash {
+							return fmt.Errorf("expect a different genesis hash after reinit")
+						}
+						if _, err := os.Stat(nodeKeyFile); err != nil {
+							return fmt.Errorf("expect nodekey to be kept: %s", err)
+						}
+						// the nodekey is not created by the resource so it would prevent the data dir from being destroyed
+						return os.Remove(nodeKeyFile)
+					},
 				),
 			},
 		},
@@ -0,0 +1,249 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Use this resource to construct `alloc` field used in the genesis file.
+//
+// Each account can be pre-funded and/or predeployed with contract code and storage. The computed `alloc_json` can be merged into the genesis JSON via `jsondecode()`.
+func resourceBootstrapGenesisAlloc() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBootstrapGenesisAllocCreate,
+		ReadContext:   resourceBootstrapGenesisAllocRead,
+		DeleteContext: resourceBootstrapGenesisAllocDelete,
+		CustomizeDiff: resourceBootstrapGenesisAllocCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"account": {
+				Type:        schema.TypeList,
+				Description: "Account to be allocated in the genesis block",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:         schema.TypeString,
+							Description:  "Address of the account in hex format",
+							Required:     true,
+							ValidateFunc: validateAddress,
+						},
+						"balance": {
+							Type:        schema.TypeString,
+							Description: "Initial balance of the account in decimal or `0x` prefixed hex format. Default is 0",
+							Optional:    true,
+							Default:     "0",
+							ValidateFunc: func(i interface{}, s string) (ws []string, es []error) {
+								if _, ok := math.ParseBig256(i.(string)); !ok {
+									es = append(es, fmt.Errorf("%s is not a valid 256-bit integer: [%s]", s, i))
+								}
+								return
+							},
+						},
+						"nonce": {
+							Type:         schema.TypeInt,
+							Description:  "Initial nonce of the account. Default is 0",
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"code": {
+							Type:         schema.TypeString,
+							Description:  "Runtime bytecode of the predeployed contract in `0x` prefixed hex format. Conflicts with `code_file`",
+							Optional:     true,
+							ValidateFunc: validateHex,
+						},
+						"code_file": {
+							Type:        schema.TypeString,
+							Description: "Path to a file containing runtime bytecode of the predeployed contract in hex format. Conflicts with `code`",
+							Optional:    true,
+						},
+						"storage": {
+							Type:        schema.TypeMap,
+							Description: "Storage of the predeployed contract. Keys and values are 32-byte words in `0x` prefixed hex format",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							ValidateFunc: func(i interface{}, s string) (ws []string, es []error) {
+								for k, v := range i.(map[string]interface{}) {
+									for _, h := range []string{k, v.(string)} {
+										if b, err := hexutil.Decode(h); err != nil {
+											es = append(es, fmt.Errorf("%s contains invalid hex value [%s] due to %s", s, h, err))
+										} else if len(b) > common.HashLength {
+											es = append(es, fmt.Errorf("%s contains value [%s] longer than %d bytes", s, h, common.HashLength))
+										}
+									}
+								}
+								return
+							},
+						},
+					},
+				},
+			},
+			"code_file_sha256": {
+				Type:        schema.TypeMap,
+				Description: "SHA-256 of `code_file` contents keyed by account address. Editing a code file replaces the resource",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"alloc_json": {
+				Type:        schema.TypeString,
+				Description: "Computed `alloc` in JSON format which can be used in genesis file",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceBootstrapGenesisAllocCreate(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	alloc := make(core.GenesisAlloc)
+	for idx, raw := range d.Get("account").([]interface{}) {
+		acc := raw.(map[string]interface{})
+		address := common.HexToAddress(acc["address"].(string))
+		if _, ok := alloc[address]; ok {
+			return attributeDiag(cty.GetAttrPath("account").IndexInt(idx).GetAttr("address"), fmt.Errorf("duplicated address [%s]", strings.ToLower(address.Hex())))
+		}
+		balance, ok := math.ParseBig256(acc["balance"].(string))
+		if !ok {
+			return attributeDiag(cty.GetAttrPath("account").IndexInt(idx).GetAttr("balance"), fmt.Errorf("invalid balance [%s]", acc["balance"]))
+		}
+		code, err := readGenesisAllocCode(acc["code"].(string), acc["code_file"].(string))
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("account").IndexInt(idx), err)
+		}
+		var storage map[common.Hash]common.Hash
+		if rawStorage := acc["storage"].(map[string]interface{}); len(rawStorage) > 0 {
+			storage = make(map[common.Hash]common.Hash, len(rawStorage))
+			for k, v := range rawStorage {
+				storage[common.HexToHash(k)] = common.HexToHash(v.(string))
+			}
+		}
+		alloc[address] = core.GenesisAccount{
+			Code:    code,
+			Storage: storage,
+			Balance: balance,
+			Nonce:   uint64(acc["nonce"].(int)),
+		}
+	}
+	allocJSON, err := json.Marshal(alloc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hashes, err := hashGenesisAllocCodeFiles(d.Get("account").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("alloc_json", string(allocJSON))
+	_ = d.Set("code_file_sha256", hashes)
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(allocJSON)))
+	return nil
+}
+
+func resourceBootstrapGenesisAllocCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("account") {
+		return d.SetNewComputed("code_file_sha256")
+	}
+	hashes, err := hashGenesisAllocCodeFiles(d.Get("account").([]interface{}))
+	if err != nil {
+		// code file may be written by another resource during apply
+		return d.SetNewComputed("code_file_sha256")
+	}
+	old, _ := d.GetChange("code_file_sha256")
+	if reflect.DeepEqual(old, hashes) {
+		return nil
+	}
+	if err := d.SetNew("code_file_sha256", hashes); err != nil {
+		return err
+	}
+	if d.Id() != "" {
+		return d.ForceNew("code_file_sha256")
+	}
+	return nil
+}
+
+func hashGenesisAllocCodeFiles(accounts []interface{}) (map[string]interface{}, error) {
+	hashes := make(map[string]interface{})
+	for _, raw := range accounts {
+		acc, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		codeFile, _ := acc["code_file"].(string)
+		if codeFile == "" {
+			continue
+		}
+		content, err := ioutil.ReadFile(codeFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read code file due to %s", err)
+		}
+		address := strings.ToLower(common.HexToAddress(acc["address"].(string)).Hex())
+		hashes[address] = fmt.Sprintf("%x", sha256.Sum256(content))
+	}
+	return hashes, nil
+}
+
+func readGenesisAllocCode(code string, codeFile string) ([]byte, error) {
+	if code != "" && codeFile != "" {
+		return nil, fmt.Errorf("only one of code or code_file can be set")
+	}
+	if codeFile != "" {
+		content, err := ioutil.ReadFile(codeFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read code file due to %s", err)
+		}
+		code = strings.TrimSpace(string(content))
+		if !strings.HasPrefix(code, "0x") && !strings.HasPrefix(code, "0X") {
+			code = "0x" + code
+		}
+	}
+	if code == "" {
+		return nil, nil
+	}
+	b, err := hexutil.Decode(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code due to %s", err)
+	}
+	return b, nil
+}
+
+func validateAddress(i interface{}, s string) (ws []string, es []error) {
+	if !common.IsHexAddress(i.(string)) {
+		es = append(es, fmt.Errorf("%s is not a valid address: [%s]", s, i))
+	}
+	return
+}
+
+func suppressAddressCaseDiff(_, old, new string, _ *schema.ResourceData) bool {
+	// EIP-55 checksummed and lowercase addresses only differ in case
+	return strings.EqualFold(old, new)
+}
+
+func validateHex(i interface{}, s string) (ws []string, es []error) {
+	if _, err := hexutil.Decode(i.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s is not a valid hex value due to %s", s, err))
+	}
+	return
+}
+
+func resourceBootstrapGenesisAllocRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceBootstrapGenesisAllocDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
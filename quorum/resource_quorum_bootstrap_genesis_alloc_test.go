@@ -0,0 +1,128 @@
+package quorum
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// @example
+func TestAccResourceBootstrapGenesisAlloc_whenTypical(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_bootstrap_genesis_alloc" "test" {
+						account {
+							address = "0xed9d02e382b34818e88b88a309c7fe71e65f419d"
+							balance = "1000000000000000000000000000"
+						}
+						account {
+							address = "0x0000000000000000000000000000000000009999"
+							nonce   = 1
+							code    = "0x6080604052"
+							storage = {
+								"0x00" = "0x01"
+							}
+						}
+					}
+                `,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_genesis_alloc.test", "id"),
+					resource.TestCheckResourceAttr("quorum_bootstrap_genesis_alloc.test", "alloc_json", `{"0x0000000000000000000000000000000000009999":{"code":"0x6080604052","storage":{"0x0000000000000000000000000000000000000000000000000000000000000000":"0x0000000000000000000000000000000000000000000000000000000000000001"},"balance":"0x0","nonce":"0x1"},"0xed9d02e382b34818e88b88a309c7fe71e65f419d":{"balance":"0x33b2e3c9fd0803ce8000000"}}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapGenesisAlloc_whenUsingCodeFile(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	codeFile := path.Join(tempdir, "contract.bin-runtime")
+	if err := ioutil.WriteFile(codeFile, []byte("6080604052\n"), 0644); err != nil {
+		t.Fatalf("can't write code file: %s", err)
+	}
+	config := fmt.Sprintf(`
+					resource "quorum_bootstrap_genesis_alloc" "test" {
+						account {
+							address   = "0x0000000000000000000000000000000000009999"
+							code_file = "%s"
+						}
+					}
+                `, codeFile)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_genesis_alloc.test", "alloc_json", `{"0x0000000000000000000000000000000000009999":{"code":"0x6080604052","balance":"0x0"}}`),
+					resource.TestCheckResourceAttr("quorum_bootstrap_genesis_alloc.test", "code_file_sha256.0x0000000000000000000000000000000000009999", "9e818f100978885e0c50a708a0b55fee892fc30d5f1db51fb7076ee34df10912"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := ioutil.WriteFile(codeFile, []byte("60806040526004\n"), 0644); err != nil {
+						t.Fatalf("can't write code file: %s", err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_genesis_alloc.test", "alloc_json", `{"0x0000000000000000000000000000000000009999":{"code":"0x60806040526004","balance":"0x0"}}`),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapGenesisAlloc_whenNegativeNonce(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_bootstrap_genesis_alloc" "test" {
+						account {
+							address = "0xed9d02e382b34818e88b88a309c7fe71e65f419d"
+							nonce   = -1
+						}
+					}
+                `,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected account.0.nonce to be at least"),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapGenesisAlloc_whenInvalidAddress(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_bootstrap_genesis_alloc" "test" {
+						account {
+							address = "0xed9d02e382b34818e88b88a309c7fe71e65f41"
+						}
+					}
+                `,
+				ExpectError: regexp.MustCompile("is not a valid address"),
+			},
+		},
+	})
+}
@@ -1,25 +1,27 @@
 package quorum
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Use this resource to construct `extradata` field used in the genesis file.
 //
 // `istanbul_address` can be referenced from `quorum_bootstrap_node_key` data source or newly created from `quorum_bootstrap_node_key` resources.
+//
+// `extradata` is computed during plan when all `istanbul_addresses` are known.
+// Otherwise it is computed during apply. The `quorum_bootstrap_istanbul_extradata` data source provides the same value without managing state.
 func resourceBootstrapIstanbulExtradata() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBootstrapIstanbulExtradataCreate,
-		Read:   resourceBootstrapIstanbulExtradataRead,
-		Delete: resourceBootstrapIstanbulExtradataDelete,
+		CreateContext: resourceBootstrapIstanbulExtradataCreate,
+		ReadContext:   resourceBootstrapIstanbulExtradataRead,
+		DeleteContext: resourceBootstrapIstanbulExtradataDelete,
+		CustomizeDiff: resourceBootstrapIstanbulExtradataCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"istanbul_addresses": {
 				Type:        schema.TypeList,
@@ -31,7 +33,7 @@ func resourceBootstrapIstanbulExtradata() *schema.Resource {
 			},
 			"mode": {
 				Type:        schema.TypeString,
-				Description: "generate extradata using RLP encoding mode. Supported: ibft1 and ibft2. Default is ibft1",
+				Description: "generate extradata using RLP encoding mode. Supported: ibft1, ibft2 and qbft. Default is ibft1",
 				Optional:    true,
 				ForceNew:    true,
 				Default:     Ibft1,
@@ -52,83 +54,60 @@ func resourceBootstrapIstanbulExtradata() *schema.Resource {
 	}
 }
 
-func resourceBootstrapIstanbulExtradataCreate(d *schema.ResourceData, _ interface{}) error {
-	addresses := d.Get("istanbul_addresses").([]interface{})
-	validators := make([]common.Address, len(addresses))
-	for idx, rawAddress := range addresses {
-		if addr, ok := rawAddress.(string); !ok {
-			return fmt.Errorf("expect string element in istanbul_addresses")
-		} else {
-			validators[idx] = common.HexToAddress(addr)
-		}
+func resourceBootstrapIstanbulExtradataCreate(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	validators, diags := toValidators(d.Get("istanbul_addresses").([]interface{}))
+	if diags.HasError() {
+		return diags
 	}
 	vanity := d.Get("vanity").(string)
 	mode := d.Get("mode").(string)
 
-	// by default, Ibft1
-	createFunc := createIbft1ExtraData
-	switch mode {
-	case Ibft2:
-		createFunc = createIbft2ExtraData
-	case Qbft:
-		createFunc = createQbftExtraData
-	}
-
-	payload, err := createFunc(validators, vanity)
+	extradata, err := createIstanbulExtraData(validators, mode, vanity)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	extradata := "0x" + common.Bytes2Hex(payload)
-	d.Set("extradata", extradata)
-	d.SetId(fmt.Sprintf("%d", time.Now().Unix()))
+	_ = d.Set("extradata", extradata)
+	d.SetId(extradataID(validators, mode, vanity))
 	return nil
 }
 
-func createIbft1ExtraData(validators []common.Address, vanity string) ([]byte, error) {
-	ist := &types.IstanbulExtra{
-		Validators:    validators,
-		Seal:          make([]byte, types.IstanbulExtraSeal),
-		CommittedSeal: [][]byte{},
+func resourceBootstrapIstanbulExtradataCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("istanbul_addresses") || !d.NewValueKnown("mode") || !d.NewValueKnown("vanity") {
+		return nil
 	}
-	payload, err := rlp.EncodeToBytes(&ist)
-	if err != nil {
-		return nil, err
-	}
-
-	newVanity, err := hexutil.Decode(vanity)
-	if err != nil {
-		return nil, err
+	addresses := d.Get("istanbul_addresses").([]interface{})
+	for idx := range addresses {
+		if !d.NewValueKnown(fmt.Sprintf("istanbul_addresses.%d", idx)) {
+			return nil
+		}
 	}
-	if len(newVanity) < types.IstanbulExtraVanity {
-		newVanity = append(newVanity, bytes.Repeat([]byte{0x00}, types.IstanbulExtraVanity-len(newVanity))...)
+	validators, diags := toValidators(addresses)
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
 	}
-	newVanity = newVanity[:types.IstanbulExtraVanity]
-	return append(newVanity, payload...), nil
-}
-
-func createIbft2ExtraData(validators []common.Address, _ string) ([]byte, error) {
-	data := &BesuExtraData{
-		Vanity:      make([]byte, 32),
-		Validators:  validators,
-		RoundNumber: make([]byte, 4),
+	extradata, err := createIstanbulExtraData(validators, d.Get("mode").(string), d.Get("vanity").(string))
+	if err != nil {
+		return err
 	}
-
-	return rlp.EncodeToBytes(data)
+	return d.SetNew("extradata", extradata)
 }
 
-// createQbftExtraData generates qbft consensus compatible extraData
-func createQbftExtraData(validators []common.Address, _ string) ([]byte, error) {
-	data := &QbftExtraData{
-		Vanity:     make([]byte, 32),
-		Validators: validators,
+func toValidators(addresses []interface{}) ([]common.Address, diag.Diagnostics) {
+	validators := make([]common.Address, len(addresses))
+	for idx, rawAddress := range addresses {
+		addr, ok := rawAddress.(string)
+		if !ok {
+			return nil, attributeDiag(cty.GetAttrPath("istanbul_addresses").IndexInt(idx), fmt.Errorf("expect string element"))
+		}
+		validators[idx] = common.HexToAddress(addr)
 	}
-	return rlp.EncodeToBytes(data)
+	return validators, nil
 }
 
-func resourceBootstrapIstanbulExtradataRead(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapIstanbulExtradataRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceBootstrapIstanbulExtradataDelete(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapIstanbulExtradataDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
@@ -1,9 +1,12 @@
 package quorum
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // @example
@@ -114,3 +117,77 @@ func TestAccResourceBootstrapQbftExtradata(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceBootstrapIstanbulExtradata_whenDeterministicID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_bootstrap_istanbul_extradata" "a" {
+						istanbul_addresses = ["0xbe2cd59dee14a4b3299d6f2da6813f2a4c7ec3f5", "0x3a71a3e8b16ae2f0bb8bf5dbc3b6a7b2bcb3f6f4"]
+						mode               = "qbft"
+					}
+
+					resource "quorum_bootstrap_istanbul_extradata" "b" {
+						istanbul_addresses = ["0xbe2cd59dee14a4b3299d6f2da6813f2a4c7ec3f5", "0x3a71a3e8b16ae2f0bb8bf5dbc3b6a7b2bcb3f6f4"]
+						mode               = "qbft"
+					}
+
+					resource "quorum_bootstrap_istanbul_extradata" "c" {
+						istanbul_addresses = ["0xbe2cd59dee14a4b3299d6f2da6813f2a4c7ec3f5", "0x3a71a3e8b16ae2f0bb8bf5dbc3b6a7b2bcb3f6f4"]
+						mode               = "ibft2"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_istanbul_extradata.a", "id", "quorum_bootstrap_istanbul_extradata.b", "id"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["quorum_bootstrap_istanbul_extradata.a"].Primary.ID == s.RootModule().Resources["quorum_bootstrap_istanbul_extradata.c"].Primary.ID {
+							return fmt.Errorf("expect different IDs for different modes")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestResourceBootstrapIstanbulExtradata_whenAddressesKnownInPlan(t *testing.T) {
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"istanbul_addresses": []interface{}{"0x8f1e6d8303716516cc9e562e66d09721752a1f83"},
+		"mode":               "qbft",
+	})
+
+	diff, err := resourceBootstrapIstanbulExtradata().Diff(context.Background(), nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attr := diff.Attributes["extradata"]
+	if attr == nil || attr.NewComputed {
+		t.Fatalf("expect extradata to be known in plan but got %v", attr)
+	}
+	if attr.New != "0xf83aa00000000000000000000000000000000000000000000000000000000000000000d5948f1e6d8303716516cc9e562e66d09721752a1f83c080c0" {
+		t.Fatalf("unexpected extradata %s", attr.New)
+	}
+}
+
+func TestResourceBootstrapIstanbulExtradata_whenAddressesUnknownInPlan(t *testing.T) {
+	// value used by Terraform to represent an unknown value in the legacy configuration
+	unknownValue := "74D93920-ED26-11E3-AC10-0800200C9A66"
+	cfg := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"istanbul_addresses": []interface{}{"0x8f1e6d8303716516cc9e562e66d09721752a1f83", unknownValue},
+	})
+
+	diff, err := resourceBootstrapIstanbulExtradata().Diff(context.Background(), nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attr := diff.Attributes["extradata"]
+	if attr == nil || !attr.NewComputed {
+		t.Fatalf("expect extradata to be computed during apply but got %v", attr)
+	}
+}
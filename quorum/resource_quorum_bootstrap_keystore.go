@@ -1,38 +1,102 @@
 package quorum
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Use this resource to create a keystore which maintains multiple Ethereum accounts.
 func resourceBootstrapKeyStore() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBootstrapKeyStoreCreate,
-		Read:   resourceBootstrapKeyStoreRead,
-		Delete: resourceBootstrapKeyStoreDelete,
-		Update: resourceBootstrapKeyStoreUpdate,
+		CreateContext: resourceBootstrapKeyStoreCreate,
+		ReadContext:   resourceBootstrapKeyStoreRead,
+		DeleteContext: resourceBootstrapKeyStoreDelete,
+		UpdateContext: resourceBootstrapKeyStoreUpdate,
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+				// blocks with the same name collapse in the set so the raw config is checked instead
+				rawConfig := d.GetRawConfig()
+				if rawConfig.IsNull() || !rawConfig.IsKnown() {
+					return nil
+				}
+				rawAccounts := rawConfig.GetAttr("account")
+				if rawAccounts.IsNull() || !rawAccounts.IsKnown() {
+					return nil
+				}
+				names := make(map[string]bool)
+				for it := rawAccounts.ElementIterator(); it.Next(); {
+					_, rawAccount := it.Element()
+					rawName := rawAccount.GetAttr("name")
+					if rawName.IsNull() || !rawName.IsKnown() {
+						continue
+					}
+					name := rawName.AsString()
+					if names[name] {
+						return fmt.Errorf("duplicated account name [%s]", name)
+					}
+					names[name] = true
+				}
+				return nil
+			},
+			customdiff.ComputedIf("accounts_by_name", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				// addresses are kept when re-encrypting so only adding or removing accounts changes them
+				o, n := d.GetChange("account")
+				oldNames, newNames := toAccountNames(o.(*schema.Set).List()), toAccountNames(n.(*schema.Set).List())
+				sort.Strings(oldNames)
+				sort.Strings(newNames)
+				return !reflect.DeepEqual(oldNames, newNames)
+			}),
+		),
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceBootstrapKeyStoreV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceBootstrapKeyStoreStateUpgradeV0,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"keystore_dir": {
 				Type:        schema.TypeString,
-				Description: "Directory contains private keys",
+				Description: "Directory contains private keys. Relative path is resolved against provider `base_dir`",
 				Required:    true,
 				ForceNew:    true,
 			},
 			"use_light_weight_kdf": {
 				Type:        schema.TypeBool,
-				Description: "True to lower the memory and CPU requirements of the key store scrypt KDF at the expense of security",
+				Description: "True to lower the memory and CPU requirements of the key store scrypt KDF at the expense of security. Default is decided by provider `default_keystore_kdf`",
 				Optional:    true,
 				ForceNew:    true,
+				Computed:    true,
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Description: "True to remove the directory on destroy even if it was not created by this provider or contains unexpected files. It must be applied before destroying",
+				Optional:    true,
 				Default:     false,
 			},
 			"keystore_dir_abs": {
@@ -41,15 +105,21 @@ func resourceBootstrapKeyStore() *schema.Resource {
 				Computed:    true,
 			},
 			"account": {
-				Type:        schema.TypeList,
-				Description: "Account being created under this keystore",
+				Type:        schema.TypeSet,
+				Description: "Account being created under this keystore. Accounts are identified by `name`",
 				Optional:    true,
-				ConfigMode:  schema.SchemaConfigModeAttr,
+				Set:         accountHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Unique name of the account within the keystore",
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
 						"passphrase": {
 							Type:        schema.TypeString,
-							Description: "Passphrase to lock/unlock the account. Default is empty",
+							Description: "Passphrase to lock/unlock the account. Changing it re-encrypts the existing key. Default is empty",
 							Default:     "",
 							Optional:    true,
 							Sensitive:   true,
@@ -73,44 +143,70 @@ func resourceBootstrapKeyStore() *schema.Resource {
 					},
 				},
 			},
+			"accounts_by_name": {
+				Type:        schema.TypeMap,
+				Description: "Addresses of the accounts keyed by account `name`",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
-func resourceBootstrapKeyStoreCreate(d *schema.ResourceData, rawConfigurer interface{}) error {
-	d.SetId(fmt.Sprintf("ks-%d", time.Now().UnixNano()))
-	keystoreDir := d.Get("keystore_dir").(string)
+func resourceBootstrapKeyStoreCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	if _, ok := d.GetOkExists("use_light_weight_kdf"); !ok {
+		_ = d.Set("use_light_weight_kdf", config.useLightWeightKDF)
+	}
+	keystoreDir := config.resolvePath(d.Get("keystore_dir").(string))
 	log.Println("[DEBUG] Keystore Directory", keystoreDir)
 	absDir, err := createDirectory(keystoreDir)
 	if err != nil {
-		return err
+		return attributeDiag(cty.GetAttrPath("keystore_dir"), err)
 	}
 	if files, err := ioutil.ReadDir(absDir); err != nil {
-		return err
+		return attributeDiag(cty.GetAttrPath("keystore_dir"), err)
 	} else {
 		if len(files) > 0 {
-			return fmt.Errorf("directory [%s] is not empty", absDir)
+			return attributeDiag(cty.GetAttrPath("keystore_dir"), fmt.Errorf("directory [%s] is not empty", absDir))
 		}
 	}
+	if err := markManagedDirectory(absDir, "quorum_bootstrap_keystore"); err != nil {
+		return attributeDiag(cty.GetAttrPath("keystore_dir"), err)
+	}
+	d.SetId(absDir)
 	_ = d.Set("keystore_dir_abs", absDir)
-	if err := resourceBootstrapKeyStoreRead(d, rawConfigurer); err != nil {
-		return err
+	if diags := resourceBootstrapKeyStoreRead(ctx, d, rawConfigurer); diags.HasError() {
+		return diags
 	}
 	ks, err := getKeystoreInstance(d.Id(), rawConfigurer)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	for _, raw := range d.Get("account").([]interface{}) {
-		if err := createNewAccount(ks, raw); err != nil {
-			return err
+	newAccounts := d.Get("account").(*schema.Set).List()
+	for _, raw := range newAccounts {
+		if err := createNewAccount(ctx, rawConfigurer.(*configurer), ks, raw); err != nil {
+			return attributeDiag(cty.GetAttrPath("account"), err)
 		}
 	}
+	_ = d.Set("account", schema.NewSet(accountHash, newAccounts))
+	_ = d.Set("accounts_by_name", toAccountsByName(newAccounts))
 	return nil
 }
 
-func createNewAccount(ks *keystore.KeyStore, raw interface{}) error {
+func accountHash(v interface{}) int {
+	acc := v.(map[string]interface{})
+	// values are quoted so different accounts can't produce the same string, e.g.: a-b/c and a/b-c
+	return schema.HashString(fmt.Sprintf("%q-%q-%q", acc["name"], acc["passphrase"], acc["balance"]))
+}
+
+func createNewAccount(ctx context.Context, config *configurer, ks *keystore.KeyStore, raw interface{}) error {
 	newAccountSchema := raw.(map[string]interface{})
-	newAcc, err := ks.NewAccount(newAccountSchema["passphrase"].(string))
+	var newAcc accounts.Account
+	err := config.runKDF(ctx, func() (err error) {
+		newAcc, err = ks.NewAccount(newAccountSchema["passphrase"].(string))
+		return
+	})
 	if err != nil {
 		return err
 	}
@@ -130,55 +226,86 @@ func getKeystoreInstance(id string, rawConfigurer interface{}) (*keystore.KeySto
 	return ksRaw.(*keystore.KeyStore), nil
 }
 
-func resourceBootstrapKeyStoreUpdate(d *schema.ResourceData, rawConfigurer interface{}) error {
-	if err := resourceBootstrapKeyStoreRead(d, rawConfigurer); err != nil {
-		return err
+func resourceBootstrapKeyStoreUpdate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	if diags := resourceBootstrapKeyStoreRead(ctx, d, rawConfigurer); diags.HasError() {
+		return diags
 	}
 	ks, err := getKeystoreInstance(d.Id(), rawConfigurer)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if d.HasChange("account") {
 		o, n := d.GetChange("account")
-		if o == nil {
-			o = make([]interface{}, 0)
+		updatedAccounts, err := updateAccounts(ctx, rawConfigurer.(*configurer), ks, o.(*schema.Set).List(), n.(*schema.Set).List())
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("account"), err)
 		}
-		if n == nil {
-			n = make([]interface{}, 0)
+		_ = d.Set("account", schema.NewSet(accountHash, updatedAccounts))
+		_ = d.Set("accounts_by_name", toAccountsByName(updatedAccounts))
+	}
+	return nil
+}
+
+func updateAccounts(ctx context.Context, config *configurer, ks *keystore.KeyStore, rawOldAccounts []interface{}, rawNewAccounts []interface{}) ([]interface{}, error) {
+	oldAccounts := toAccountsMap(rawOldAccounts)
+	newAccounts := toAccountsMap(rawNewAccounts)
+	// delete accounts whose names are removed
+	for name, oldAcc := range oldAccounts {
+		if _, ok := newAccounts[name]; ok {
+			continue
 		}
-		oldAccountSet := o.([]interface{})
-		newAccountSet := n.([]interface{})
-		existingAccountSet := make(map[string]bool)
-		// create new accounts
-		for _, raw := range newAccountSet {
-			log.Println("[DEBUG] Diff New:", raw)
-			acc := raw.(map[string]interface{})
-			accountAddress := acc["address"].(string)
-			if accountAddress == "" {
-				if err := createNewAccount(ks, raw); err != nil {
-					return err
-				}
-			} else {
-				existingAccountSet[accountAddress] = true
+		log.Println("[DEBUG] Deleting account", name, oldAcc["address"])
+		if err := os.Remove(oldAcc["account_url"].(string)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("can't delete account [%s] due to %s", name, err)
+		}
+	}
+	// keep the order of new accounts
+	updatedAccounts := make([]interface{}, 0, len(rawNewAccounts))
+	for _, raw := range rawNewAccounts {
+		newAcc := raw.(map[string]interface{})
+		name := newAcc["name"].(string)
+		updatedAccounts = append(updatedAccounts, newAcc)
+		oldAcc, ok := oldAccounts[name]
+		if !ok {
+			log.Println("[DEBUG] Creating account", name)
+			if err := createNewAccount(ctx, config, ks, newAcc); err != nil {
+				return nil, fmt.Errorf("can't create account [%s] due to %s", name, err)
 			}
+			continue
 		}
-		// delete old accounts
-		for _, raw := range oldAccountSet {
-			log.Println("[DEBUG] Diff Old:", raw)
-			acc := raw.(map[string]interface{})
-			accountAddress := acc["address"].(string)
-			if _, ok := existingAccountSet[accountAddress]; !ok {
-				log.Println("[DEBUG] Deleting account", accountAddress)
-				if err := os.Remove(acc["account_url"].(string)); err != nil {
-					return err
-				}
+		newAcc["address"], newAcc["account_url"] = oldAcc["address"], oldAcc["account_url"]
+		if oldAcc["passphrase"] != newAcc["passphrase"] {
+			log.Println("[DEBUG] Changing passphrase of account", name)
+			acc := accounts.Account{Address: common.HexToAddress(oldAcc["address"].(string))}
+			if err := config.runKDF(ctx, func() error {
+				return ks.Update(acc, oldAcc["passphrase"].(string), newAcc["passphrase"].(string))
+			}); err != nil {
+				return nil, fmt.Errorf("can't change passphrase of account [%s] due to %s", name, err)
 			}
 		}
 	}
-	return nil
+	return updatedAccounts, nil
+}
+
+func toAccountsMap(rawAccounts []interface{}) map[string]map[string]interface{} {
+	m := make(map[string]map[string]interface{})
+	for _, raw := range rawAccounts {
+		acc := raw.(map[string]interface{})
+		m[acc["name"].(string)] = acc
+	}
+	return m
 }
 
-func resourceBootstrapKeyStoreRead(d *schema.ResourceData, rawConfigurer interface{}) error {
+func toAccountsByName(rawAccounts []interface{}) map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, raw := range rawAccounts {
+		acc := raw.(map[string]interface{})
+		m[acc["name"].(string)] = acc["address"]
+	}
+	return m
+}
+
+func resourceBootstrapKeyStoreRead(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
 	absDir := d.Get("keystore_dir_abs").(string)
 	sn, sp := keystore.StandardScryptN, keystore.StandardScryptP
 	useLightWeightKDF := d.Get("use_light_weight_kdf").(bool)
@@ -189,13 +316,21 @@ func resourceBootstrapKeyStoreRead(d *schema.ResourceData, rawConfigurer interfa
 	// save into registry so if it can be retrieved later if needed
 	config := rawConfigurer.(*configurer)
 	config.registry.set(d.Id(), ks)
+	if d.Get("account").(*schema.Set).Len() > 0 {
+		_ = d.Set("accounts_by_name", toAccountsByName(d.Get("account").(*schema.Set).List()))
+	}
 	return nil
 }
 
-func resourceBootstrapKeyStoreDelete(d *schema.ResourceData, raw interface{}) error {
-	keyDir := d.Get("keystore_dir").(string)
+func resourceBootstrapKeyStoreDelete(_ context.Context, d *schema.ResourceData, raw interface{}) diag.Diagnostics {
+	keyDir := d.Get("keystore_dir_abs").(string)
 	log.Println("[DEBUG] Deleting keystore", keyDir)
+	// key files are named as UTC--<created_at>--<address>. An interrupted key generation may leave
+	// a temporary .UTC--<created_at>--<address>.tmp<random> file behind
+	if err := removeManagedDirectory(keyDir, d.Get("force_destroy").(bool), "UTC--*", ".UTC--*.tmp*"); err != nil {
+		return diag.FromErr(err)
+	}
 	raw.(*configurer).registry.delete(d.Id())
 	d.SetId("")
-	return os.RemoveAll(keyDir)
+	return nil
 }
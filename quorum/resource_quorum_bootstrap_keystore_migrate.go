@@ -0,0 +1,72 @@
+package quorum
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// schema version 0 where `account` is a list and accounts are identified by their positions
+func resourceBootstrapKeyStoreV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"keystore_dir": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"use_light_weight_kdf": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+			"keystore_dir_abs": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account": {
+				Type:       schema.TypeList,
+				Optional:   true,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"passphrase": {
+							Type:      schema.TypeString,
+							Default:   "",
+							Optional:  true,
+							Sensitive: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_url": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"balance": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// accounts in version 0 are named after their positions in the list
+// so existing configuration can adopt the same names to keep the key files
+func resourceBootstrapKeyStoreStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawAccounts, ok := rawState["account"].([]interface{}); ok {
+		for idx, raw := range rawAccounts {
+			if acc, ok := raw.(map[string]interface{}); ok {
+				acc["name"] = strconv.Itoa(idx)
+			}
+		}
+	}
+	return rawState, nil
+}
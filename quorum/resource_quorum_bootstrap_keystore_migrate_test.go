@@ -0,0 +1,45 @@
+package quorum
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBootstrapKeyStoreStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"keystore_dir":         "/tmp/ks",
+		"keystore_dir_abs":     "/tmp/ks",
+		"use_light_weight_kdf": true,
+		"account": []interface{}{
+			map[string]interface{}{"passphrase": "", "address": "0x1", "account_url": "/tmp/ks/1", "balance": ""},
+			map[string]interface{}{"passphrase": "p", "address": "0x2", "account_url": "/tmp/ks/2", "balance": "10"},
+		},
+	}
+	expected := map[string]interface{}{
+		"keystore_dir":         "/tmp/ks",
+		"keystore_dir_abs":     "/tmp/ks",
+		"use_light_weight_kdf": true,
+		"account": []interface{}{
+			map[string]interface{}{"name": "0", "passphrase": "", "address": "0x1", "account_url": "/tmp/ks/1", "balance": ""},
+			map[string]interface{}{"name": "1", "passphrase": "p", "address": "0x2", "account_url": "/tmp/ks/2", "balance": "10"},
+		},
+	}
+
+	actual, err := resourceBootstrapKeyStoreStateUpgradeV0(context.Background(), v0, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestResourceBootstrapKeyStoreStateUpgradeV0_whenNoAccount(t *testing.T) {
+	v0 := map[string]interface{}{
+		"keystore_dir": "/tmp/ks",
+	}
+
+	actual, err := resourceBootstrapKeyStoreStateUpgradeV0(context.Background(), v0, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"keystore_dir": "/tmp/ks"}, actual)
+}
@@ -4,11 +4,17 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // @example
@@ -28,8 +34,10 @@ func TestAccResourceBootstrapKeyStore_whenTypical(t *testing.T) {
 			                        keystore_dir = "%s"
 									use_light_weight_kdf = false
 									account {
+										name = "acc0"
 									}
 									account {
+										name = "acc1"
 										passphrase = "acc1"
 									}
 			                    }
@@ -40,11 +48,18 @@ func TestAccResourceBootstrapKeyStore_whenTypical(t *testing.T) {
 					//	return nil
 					//},
 					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "keystore_dir_abs"),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_keystore.test", "id", "quorum_bootstrap_keystore.test", "keystore_dir_abs"),
 					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "account.#", "2"),
-					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "account.0.address"),
-					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "account.0.account_url"),
-					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "account.1.address"),
-					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "account.1.account_url"),
+					resource.TestMatchTypeSetElemNestedAttrs("quorum_bootstrap_keystore.test", "account.*", map[string]*regexp.Regexp{
+						"name":        regexp.MustCompile("^acc0$"),
+						"address":     regexp.MustCompile("^0x[0-9a-f]{40}$"),
+						"account_url": regexp.MustCompile(".+"),
+					}),
+					resource.TestMatchTypeSetElemNestedAttrs("quorum_bootstrap_keystore.test", "account.*", map[string]*regexp.Regexp{
+						"name":        regexp.MustCompile("^acc1$"),
+						"address":     regexp.MustCompile("^0x[0-9a-f]{40}$"),
+						"account_url": regexp.MustCompile(".+"),
+					}),
 				),
 			},
 		},
@@ -70,11 +85,13 @@ func TestAccResourceBootstrapKeyStore_whenUpdate(t *testing.T) {
 			                        keystore_dir = "%s"
 									use_light_weight_kdf = false
 									account {
+										name = "1"
 										passphrase = "1"
 									}
 									account {
+										name = "2"
 										passphrase = "2"
-									}	
+									}
 			                    }
 			                `, tempdir),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -87,6 +104,7 @@ func TestAccResourceBootstrapKeyStore_whenUpdate(t *testing.T) {
 			                        keystore_dir = "%s"
 									use_light_weight_kdf = false
 									account {
+										name = "3"
 										passphrase = "3"
 									}
 			                    }
@@ -101,3 +119,320 @@ func TestAccResourceBootstrapKeyStore_whenUpdate(t *testing.T) {
 	_, err = os.Stat(tempdir)
 	assert.True(t, os.IsNotExist(err))
 }
+
+func TestAccResourceBootstrapKeyStore_whenPassphraseChanged(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	// the dependent keystore is replaced, generating a new account, if accounts_by_name becomes unknown
+	config := func(passphrase string) string {
+		return fmt.Sprintf(`
+resource "quorum_bootstrap_keystore" "test" {
+  keystore_dir         = "%s/test"
+  use_light_weight_kdf = true
+  account {
+    name       = "acc0"
+    passphrase = "%s"
+  }
+}
+
+resource "quorum_bootstrap_keystore" "dependent" {
+  keystore_dir         = "%s/${quorum_bootstrap_keystore.test.accounts_by_name["acc0"]}"
+  use_light_weight_kdf = true
+  account {
+    name = "acc0"
+  }
+}
+`, tempdir, passphrase, tempdir)
+	}
+	var dependentAddress string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config("old"),
+				Check: func(s *terraform.State) error {
+					dependentAddress = s.RootModule().Resources["quorum_bootstrap_keystore.dependent"].Primary.Attributes["accounts_by_name.acc0"]
+					return nil
+				},
+			},
+			{
+				Config: config("new"),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, dependentAddress, s.RootModule().Resources["quorum_bootstrap_keystore.dependent"].Primary.Attributes["accounts_by_name.acc0"])
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapKeyStore_whenUsingProviderDefaultKDF(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "quorum" {
+						default_keystore_kdf = "light"
+					}
+
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						account {
+							name = "default"
+						}
+					}
+				`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "use_light_weight_kdf", "true"),
+					resource.TestMatchTypeSetElemNestedAttrs("quorum_bootstrap_keystore.test", "account.*", map[string]*regexp.Regexp{
+						"name":    regexp.MustCompile("^default$"),
+						"address": regexp.MustCompile("^0x[0-9a-f]{40}$"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapKeyStore_whenTimeout(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "quorum" {
+						max_concurrent_kdf = 1
+					}
+
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						use_light_weight_kdf = false
+						account {
+							name = "slow"
+						}
+
+						timeouts {
+							create = "1ns"
+						}
+					}
+				`, tempdir),
+				ExpectError: regexp.MustCompile("context deadline exceeded"),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapKeyStore_whenRemovingMiddleAccount(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	var addresses map[string]string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						use_light_weight_kdf = true
+						account {
+							name = "a"
+						}
+						account {
+							name = "b"
+						}
+						account {
+							name = "c"
+						}
+					}
+				`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "account.#", "3"),
+					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "accounts_by_name.%", "3"),
+					func(s *terraform.State) error {
+						addresses = toAccountsByNameFromState(s)
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						use_light_weight_kdf = true
+						account {
+							name = "c"
+						}
+						account {
+							name = "a"
+						}
+					}
+				`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "account.#", "2"),
+					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "accounts_by_name.%", "2"),
+					func(s *terraform.State) error {
+						assert.Equal(t, map[string]string{"a": addresses["a"], "c": addresses["c"]}, toAccountsByNameFromState(s))
+						files, err := filepath.Glob(path.Join(tempdir, "UTC--*"))
+						if err != nil {
+							return err
+						}
+						assert.Len(t, files, 2)
+						for _, f := range files {
+							assert.NotContains(t, f, strings.TrimPrefix(addresses["b"], "0x"), "key file of removed account must be deleted")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapKeyStore_whenChangingPassphrase(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	var address string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						use_light_weight_kdf = true
+						account {
+							name = "a"
+							passphrase = "old"
+						}
+					}
+				`, tempdir),
+				Check: func(s *terraform.State) error {
+					address = toAccountsByNameFromState(s)["a"]
+					return nil
+				},
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						use_light_weight_kdf = true
+						account {
+							name = "a"
+							passphrase = "new"
+						}
+					}
+				`, tempdir),
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, address, toAccountsByNameFromState(s)["a"], "account must be kept")
+					files, err := filepath.Glob(path.Join(tempdir, "UTC--*"))
+					if err != nil {
+						return err
+					}
+					if !assert.Len(t, files, 1) {
+						return nil
+					}
+					keyJSON, err := ioutil.ReadFile(files[0])
+					if err != nil {
+						return err
+					}
+					_, err = keystore.DecryptKey(keyJSON, "new")
+					assert.NoError(t, err)
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapKeyStore_whenDuplicatedName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "not-used"
+						account {
+							name = "a"
+						}
+						account {
+							name = "a"
+							passphrase = "other"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("duplicated account name \\[a\\]"),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapKeyStore_whenNamesLookAlike(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir = "%s"
+						account {
+							name       = "a-b"
+							passphrase = "c"
+						}
+						account {
+							name       = "a"
+							passphrase = "b-c"
+						}
+					}
+				`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_keystore.test", "account.#", "2"),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "accounts_by_name.a"),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_keystore.test", "accounts_by_name.a-b"),
+				),
+			},
+		},
+	})
+}
+
+func toAccountsByNameFromState(s *terraform.State) map[string]string {
+	m := make(map[string]string)
+	for k, v := range s.RootModule().Resources["quorum_bootstrap_keystore.test"].Primary.Attributes {
+		if strings.HasPrefix(k, "accounts_by_name.") && k != "accounts_by_name.%" {
+			m[strings.TrimPrefix(k, "accounts_by_name.")] = v
+		}
+	}
+	return m
+}
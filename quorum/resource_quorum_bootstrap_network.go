@@ -1,20 +1,28 @@
 package quorum
 
 import (
-	"os"
+	"context"
+	"io/ioutil"
 	"path"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Use this resource to create a new directory that represents a new Quorum network.
 //
 // Bootstraping data will be kept in this folder.
+//
+// A `network.json` manifest is maintained in the directory. It lists chain ID, genesis hash and every `quorum_bootstrap_node`
+// whose `network_dir` references this network, with its enode URL, Istanbul address, accounts, transaction manager public key and ports.
+// Nodes listed in an existing manifest are kept. An existing non-empty directory is not marked as managed so it is only removed with `force_destroy`.
 func resourceBootstrapNetwork() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBootstrapNetworkCreate,
-		Read:   resourceBootstrapNetworkRead,
-		Delete: resourceBootstrapNetworkDelete,
+		CreateContext: resourceBootstrapNetworkCreate,
+		ReadContext:   resourceBootstrapNetworkRead,
+		UpdateContext: resourceBootstrapNetworkUpdate,
+		DeleteContext: resourceBootstrapNetworkDelete,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -25,39 +33,83 @@ func resourceBootstrapNetwork() *schema.Resource {
 			},
 			"target_dir": {
 				Type:        schema.TypeString,
-				Description: "File system path to the directory on which new directory will be created. Default is current working directory",
+				Description: "File system path to the directory on which new directory will be created. Relative path is resolved against provider `base_dir`. Default is current working directory",
 				Optional:    true,
 				ForceNew:    true,
 				Default:     ".",
 			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Description: "True to remove the directory on destroy even if it was not created by this provider or contains unexpected files. It must be applied before destroying",
+				Optional:    true,
+				Default:     false,
+			},
 			"network_dir_abs": {
 				Type:        schema.TypeString,
 				Description: "Absolute path to a directory representing this new network",
 				Computed:    true,
 			},
+			"manifest_file": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to `network.json` manifest describing the network",
+				Computed:    true,
+			},
 		},
 	}
 }
 
-func resourceBootstrapNetworkCreate(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapNetworkCreate(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
 	name := d.Get("name").(string)
-	targetDir := d.Get("target_dir").(string)
-	d.SetId(name)
-	absDir, err := createDirectory(path.Join(targetDir, name))
+	targetDir := config.resolvePath(d.Get("target_dir").(string))
+	networkDir := path.Join(targetDir, name)
+	// only a directory created by this resource is marked as managed, an existing one requires force_destroy
+	adopted := false
+	if files, err := ioutil.ReadDir(networkDir); err == nil && len(files) > 0 {
+		adopted = true
+	}
+	absDir, err := createDirectory(networkDir)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("target_dir"), err)
+	}
+	// nodes listed in an existing manifest are kept
+	manifest, err := lookupNetworkManifest(config, absDir)
 	if err != nil {
-		return err
+		return attributeDiag(cty.GetAttrPath("target_dir"), err)
 	}
+	if manifest == nil {
+		manifest = newNetworkManifest(absDir, name)
+		config.registry.set(absDir, manifest)
+	}
+	if err := manifest.rename(name); err != nil {
+		return diag.FromErr(err)
+	}
+	if !adopted {
+		if err := markManagedDirectory(absDir, "quorum_bootstrap_network"); err != nil {
+			return attributeDiag(cty.GetAttrPath("target_dir"), err)
+		}
+	}
+	d.SetId(absDir)
 	_ = d.Set("network_dir_abs", absDir)
+	_ = d.Set("manifest_file", manifest.file)
 	return nil
 }
 
-func resourceBootstrapNetworkRead(_ *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapNetworkRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceBootstrapNetworkDelete(d *schema.ResourceData, _ interface{}) error {
-	d.SetId("")
+func resourceBootstrapNetworkUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only force_destroy can be updated and it is used during deletion
+	return nil
+}
+
+func resourceBootstrapNetworkDelete(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
 	dir := d.Get("network_dir_abs").(string)
-	_ = os.RemoveAll(dir)
+	if err := removeManagedDirectory(dir, d.Get("force_destroy").(bool), networkManifestFileName); err != nil {
+		return diag.FromErr(err)
+	}
+	rawConfigurer.(*configurer).registry.delete(d.Id())
+	d.SetId("")
 	return nil
 }
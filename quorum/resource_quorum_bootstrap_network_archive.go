@@ -0,0 +1,248 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to package a network directory created by `quorum_bootstrap_network` into `tar.gz` archives,
+// e.g. to ship it to an air-gapped environment.
+//
+// Archives are deterministic: file timestamps and ownership are not recorded so the same directory content always produces the same archive.
+// Each archive contains a `SHA256SUMS` file listing the checksum of every archived file. Another `SHA256SUMS` file listing the archives is written to `target_dir`.
+//
+// The archive is recreated if it is changed or removed outside of Terraform, or if the archived content of the network directory changes. Use `depends_on` so the archive is created after all nodes.
+func resourceBootstrapNetworkArchive() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBootstrapNetworkArchiveCreate,
+		ReadContext:   resourceBootstrapNetworkArchiveRead,
+		DeleteContext: resourceBootstrapNetworkArchiveDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_dir": {
+				Type:        schema.TypeString,
+				Description: "Directory of the network to be archived, e.g.: `network_dir_abs` of `quorum_bootstrap_network`. Relative path is resolved against provider `base_dir`",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"target_dir": {
+				Type:        schema.TypeString,
+				Description: "Directory in which the archives are written. It must not be inside `network_dir`. Relative path is resolved against provider `base_dir`",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"per_node": {
+				Type:        schema.TypeBool,
+				Description: "True to create one archive for each node directory instead of one for the whole network. Files at the top level of the network directory such as `network.json` are included in every archive",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"exclude_secrets": {
+				Type:        schema.TypeBool,
+				Description: "True to exclude private key material: `keystore` directories, `nodekey` and `*.key` files",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Description: "Additional glob patterns of files or directories to exclude. A pattern is matched against the file name and the slash separated path relative to `network_dir`",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"archives": {
+				Type:        schema.TypeMap,
+				Description: "Absolute paths of the archives keyed by network name or node directory name when `per_node` is true",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"checksums": {
+				Type:        schema.TypeMap,
+				Description: "SHA-256 checksums of the archives in hex, keyed the same as `archives`",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA-256 digest of the archived paths, modes and file contents. The archives are recreated when the network directory changes",
+				Computed:    true,
+			},
+			"checksums_file": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to `SHA256SUMS` file listing the archives, which can be verified using `sha256sum -c`",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceBootstrapNetworkArchiveCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	networkDir, err := filepath.Abs(config.resolvePath(d.Get("network_dir").(string)))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	if info, err := os.Stat(networkDir); err != nil || !info.IsDir() {
+		return attributeDiag(cty.GetAttrPath("network_dir"), fmt.Errorf("directory [%s] does not exist", networkDir))
+	}
+	targetDir, err := createDirectory(config.resolvePath(d.Get("target_dir").(string)))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("target_dir"), err)
+	}
+	if rel, err := filepath.Rel(networkDir, targetDir); err == nil && !strings.HasPrefix(rel, "..") {
+		return attributeDiag(cty.GetAttrPath("target_dir"), fmt.Errorf("directory [%s] must not be inside the network directory", targetDir))
+	}
+	archiveEntries, diags := collectNetworkArchiveEntries(ctx, d, networkDir)
+	if diags.HasError() {
+		return diags
+	}
+	sourceChecksum, err := archiveEntriesChecksum(archiveEntries)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	networkName := filepath.Base(networkDir)
+	names := make([]string, 0, len(archiveEntries))
+	for name := range archiveEntries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	archives, checksums := make(map[string]interface{}), make(map[string]interface{})
+	var sums strings.Builder
+	for _, name := range names {
+		file := filepath.Join(targetDir, name+".tar.gz")
+		var checksum string
+		if err := runWithContext(ctx, func() (err error) {
+			checksum, err = writeArchive(file, networkName, archiveEntries[name])
+			return
+		}); err != nil {
+			return diag.Errorf("can't create archive %s due to %s", file, err)
+		}
+		log.Println("[DEBUG] Archive is created", file, checksum)
+		archives[name], checksums[name] = file, checksum
+		_, _ = fmt.Fprintf(&sums, "%s  %s\n", checksum, filepath.Base(file))
+	}
+	checksumsFile := filepath.Join(targetDir, checksumsFileName)
+	if err := ioutil.WriteFile(checksumsFile, []byte(sums.String()), 0644); err != nil {
+		return diag.Errorf("can't write %s due to %s", checksumsFile, err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(sums.String()))))
+	_ = d.Set("archives", archives)
+	_ = d.Set("checksums", checksums)
+	_ = d.Set("checksums_file", checksumsFile)
+	_ = d.Set("source_sha256", sourceChecksum)
+	return nil
+}
+
+func collectNetworkArchiveEntries(ctx context.Context, d *schema.ResourceData, networkDir string) (map[string][]*archiveEntry, diag.Diagnostics) {
+	patterns := make([]string, 0)
+	if d.Get("exclude_secrets").(bool) {
+		patterns = append(patterns, secretFilePatterns...)
+	}
+	for _, p := range d.Get("exclude").([]interface{}) {
+		patterns = append(patterns, p.(string))
+	}
+	exclude := func(rel string, name string) bool {
+		return name == managedDirectoryMarker || matchAny(name, patterns) || matchAny(rel, patterns)
+	}
+	networkName := filepath.Base(networkDir)
+	// archive name -> entries
+	archiveEntries := make(map[string][]*archiveEntry)
+	err := runWithContext(ctx, func() error {
+		all, err := collectArchiveEntries(networkDir, networkName, exclude)
+		if err != nil {
+			return err
+		}
+		if !d.Get("per_node").(bool) {
+			archiveEntries[networkName] = all
+			return nil
+		}
+		shared := make([]*archiveEntry, 0)
+		for _, e := range all {
+			if e.file != "" && path.Dir(e.name) == networkName {
+				shared = append(shared, e)
+			}
+		}
+		for _, e := range all {
+			if e.file == "" && path.Dir(strings.TrimSuffix(e.name, "/")) == networkName {
+				nodeName := path.Base(e.name)
+				nodeEntries := append([]*archiveEntry{}, shared...)
+				for _, ne := range all {
+					if strings.HasPrefix(ne.name, e.name) {
+						nodeEntries = append(nodeEntries, ne)
+					}
+				}
+				sort.Slice(nodeEntries, func(i, j int) bool { return nodeEntries[i].name < nodeEntries[j].name })
+				archiveEntries[nodeName] = nodeEntries
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	if len(archiveEntries) == 0 {
+		return nil, attributeDiag(cty.GetAttrPath("per_node"), fmt.Errorf("no node directory found in [%s]", networkDir))
+	}
+	return archiveEntries, nil
+}
+
+func resourceBootstrapNetworkArchiveRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	// the network directory is read again to detect changes, e.g.: a regenerated node or new keystore files
+	if _, ok := d.GetOk("source_sha256"); ok {
+		config := rawConfigurer.(*configurer)
+		var checksum string
+		if networkDir, err := filepath.Abs(config.resolvePath(d.Get("network_dir").(string))); err == nil {
+			if archiveEntries, diags := collectNetworkArchiveEntries(ctx, d, networkDir); !diags.HasError() {
+				checksum, _ = archiveEntriesChecksum(archiveEntries)
+			}
+		}
+		if checksum != d.Get("source_sha256").(string) {
+			log.Println("[WARN] Network directory is changed or removed, recreating", d.Id())
+			d.SetId("")
+			return nil
+		}
+	}
+	checksums := d.Get("checksums").(map[string]interface{})
+	for name, file := range d.Get("archives").(map[string]interface{}) {
+		checksum, err := fileSHA256(file.(string))
+		if err != nil || checksum != checksums[name] {
+			log.Println("[WARN] Archive is changed or removed, recreating", file)
+			d.SetId("")
+			return nil
+		}
+	}
+	return nil
+}
+
+func resourceBootstrapNetworkArchiveDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	files := []string{d.Get("checksums_file").(string)}
+	for _, file := range d.Get("archives").(map[string]interface{}) {
+		files = append(files, file.(string))
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
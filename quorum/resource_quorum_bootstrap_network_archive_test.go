@@ -0,0 +1,253 @@
+package quorum
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAccNetworkArchiveBaseConfig(tempdir string) string {
+	return fmt.Sprintf(`
+resource "quorum_bootstrap_network" "test" {
+  name       = "test-network"
+  target_dir = "%s"
+}
+
+resource "quorum_bootstrap_node" "test" {
+  count                = 2
+  network_dir          = quorum_bootstrap_network.test.id
+  name                 = "node-${count.index}"
+  use_light_weight_kdf = true
+  genesis = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+
+  account {
+    name = "default"
+  }
+}
+`, tempdir)
+}
+
+func listArchive(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gr)
+	names := make([]string, 0)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, hdr.Name)
+	}
+	return names, nil
+}
+
+// @example
+func TestAccResourceBootstrapNetworkArchive_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkArchiveBaseConfig(tempdir) + fmt.Sprintf(`
+resource "quorum_bootstrap_network_archive" "test" {
+  network_dir     = quorum_bootstrap_network.test.network_dir_abs
+  target_dir      = "%s/archives"
+  exclude_secrets = true
+
+  depends_on = [quorum_bootstrap_node.test]
+}
+
+resource "quorum_bootstrap_network_archive" "same" {
+  network_dir     = quorum_bootstrap_network.test.network_dir_abs
+  target_dir      = "%s/same-archives"
+  exclude_secrets = true
+
+  depends_on = [quorum_bootstrap_node.test]
+}
+`, tempdir, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_network_archive.test", "archives.test-network", filepath.Join(tempdir, "archives", "test-network.tar.gz")),
+					resource.TestCheckResourceAttr("quorum_bootstrap_network_archive.test", "checksums_file", filepath.Join(tempdir, "archives", "SHA256SUMS")),
+					resource.TestMatchResourceAttr("quorum_bootstrap_network_archive.test", "checksums.test-network", regexp.MustCompile("^[0-9a-f]{64}$")),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_network_archive.test", "checksums.test-network", "quorum_bootstrap_network_archive.same", "checksums.test-network"),
+					func(_ *terraform.State) error {
+						names, err := listArchive(filepath.Join(tempdir, "archives", "test-network.tar.gz"))
+						if err != nil {
+							return err
+						}
+						assert.Contains(t, names, "test-network/network.json")
+						assert.Contains(t, names, "test-network/SHA256SUMS")
+						assert.Contains(t, names, "test-network/node-0/tm/tm.pub")
+						assert.Contains(t, names, "test-network/node-1/geth/chaindata/")
+						assert.True(t, sort.StringsAreSorted(names[:len(names)-1]), "entries must be sorted")
+						for _, n := range names {
+							assert.NotRegexp(t, `keystore|nodekey|\.key$|\.terraform-provider-quorum`, n)
+						}
+						sums, err := ioutil.ReadFile(filepath.Join(tempdir, "archives", "SHA256SUMS"))
+						if err != nil {
+							return err
+						}
+						assert.Regexp(t, `^[0-9a-f]{64}  test-network\.tar\.gz\n$`, string(sums))
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNetworkArchive_whenPerNode(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkArchiveBaseConfig(tempdir) + fmt.Sprintf(`
+resource "quorum_bootstrap_network_archive" "test" {
+  network_dir = quorum_bootstrap_network.test.network_dir_abs
+  target_dir  = "%s/archives"
+  per_node    = true
+  exclude     = ["node-*/tm"]
+
+  depends_on = [quorum_bootstrap_node.test]
+}
+`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_network_archive.test", "archives.%", "2"),
+					func(_ *terraform.State) error {
+						names, err := listArchive(filepath.Join(tempdir, "archives", "node-1.tar.gz"))
+						if err != nil {
+							return err
+						}
+						assert.Contains(t, names, "test-network/network.json")
+						assert.Contains(t, names, "test-network/node-1/geth/nodekey")
+						assert.Contains(t, names, "test-network/node-1/keystore/")
+						for _, n := range names {
+							assert.NotContains(t, n, "node-0")
+							assert.NotContains(t, n, "/tm/")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					_ = os.Remove(filepath.Join(tempdir, "archives", "node-0.tar.gz"))
+				},
+				Config: testAccNetworkArchiveBaseConfig(tempdir) + fmt.Sprintf(`
+resource "quorum_bootstrap_network_archive" "test" {
+  network_dir = quorum_bootstrap_network.test.network_dir_abs
+  target_dir  = "%s/archives"
+  per_node    = true
+  exclude     = ["node-*/tm"]
+
+  depends_on = [quorum_bootstrap_node.test]
+}
+`, tempdir),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNetworkArchive_whenNetworkDirChanged(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	config := testAccNetworkArchiveBaseConfig(tempdir) + fmt.Sprintf(`
+resource "quorum_bootstrap_network_archive" "test" {
+  network_dir = quorum_bootstrap_network.test.network_dir_abs
+  target_dir  = "%s/archives"
+
+  depends_on = [quorum_bootstrap_node.test]
+}
+`, tempdir)
+	var sourceChecksum string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("quorum_bootstrap_network_archive.test", "source_sha256", regexp.MustCompile("^[0-9a-f]{64}$")),
+					func(s *terraform.State) error {
+						sourceChecksum = s.RootModule().Resources["quorum_bootstrap_network_archive.test"].Primary.Attributes["source_sha256"]
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					keyFiles, err := filepath.Glob(filepath.Join(tempdir, "test-network", "node-1", "keystore", "UTC--*"))
+					if err != nil || len(keyFiles) != 1 {
+						t.Fatalf("expect 1 key file but got %v: %v", keyFiles, err)
+					}
+					content, err := ioutil.ReadFile(keyFiles[0])
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := ioutil.WriteFile(keyFiles[0]+"-copy", content, 0600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					assert.NotEqual(t, sourceChecksum, s.RootModule().Resources["quorum_bootstrap_network_archive.test"].Primary.Attributes["source_sha256"])
+					names, err := listArchive(filepath.Join(tempdir, "archives", "test-network.tar.gz"))
+					if err != nil {
+						return err
+					}
+					copies := 0
+					for _, n := range names {
+						if regexp.MustCompile(`^test-network/node-1/keystore/UTC--.+-copy$`).MatchString(n) {
+							copies++
+						}
+					}
+					assert.Equal(t, 1, copies, "new key file must be archived")
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -1,12 +1,17 @@
 package quorum
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
+	"path"
+	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // @example
@@ -34,3 +39,234 @@ func TestAccResourceBootstrapNetwork_whenTypical(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceBootstrapNetwork_whenUsingProviderBaseDir(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "quorum" {
+						base_dir = "%s"
+					}
+
+					resource "quorum_bootstrap_network" "test" {
+						name       = "test-network"
+						target_dir = "networks"
+					}
+                `, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_network.test", "network_dir_abs", path.Join(tempdir, "networks", "test-network")),
+					resource.TestCheckResourceAttr("quorum_bootstrap_network.test", "id", path.Join(tempdir, "networks", "test-network")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNetwork_whenManifestTracksNodes(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	config := func(count int) string {
+		return fmt.Sprintf(`
+resource "quorum_bootstrap_network" "test" {
+  name       = "test-network"
+  target_dir = "%s"
+}
+
+resource "quorum_bootstrap_node" "test" {
+  count                = %d
+  network_dir          = quorum_bootstrap_network.test.id
+  name                 = "node-${count.index}"
+  p2p_port             = 21000 + count.index
+  use_light_weight_kdf = true
+  genesis = jsonencode({
+    config     = { chainId = 1337, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+
+  account {
+    name = "default"
+  }
+}
+`, tempdir, count)
+	}
+	readManifest := func(s *terraform.State) (map[string]interface{}, error) {
+		var manifest map[string]interface{}
+		raw, err := ioutil.ReadFile(s.RootModule().Resources["quorum_bootstrap_network.test"].Primary.Attributes["manifest_file"])
+		if err != nil {
+			return nil, err
+		}
+		return manifest, json.Unmarshal(raw, &manifest)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_network.test", "manifest_file", path.Join(tempdir, "test-network", "network.json")),
+					func(s *terraform.State) error {
+						manifest, err := readManifest(s)
+						if err != nil {
+							return err
+						}
+						if manifest["name"] != "test-network" || manifest["chain_id"] != float64(1337) {
+							return fmt.Errorf("unexpected manifest %v", manifest)
+						}
+						if manifest["genesis_hash"] != s.RootModule().Resources["quorum_bootstrap_node.test.0"].Primary.Attributes["genesis_hash"] {
+							return fmt.Errorf("unexpected genesis hash %v", manifest["genesis_hash"])
+						}
+						nodes := manifest["nodes"].(map[string]interface{})
+						if len(nodes) != 2 {
+							return fmt.Errorf("expect 2 nodes but got %d", len(nodes))
+						}
+						for i := 0; i < 2; i++ {
+							attrs := s.RootModule().Resources[fmt.Sprintf("quorum_bootstrap_node.test.%d", i)].Primary.Attributes
+							n := nodes[fmt.Sprintf("node-%d", i)].(map[string]interface{})
+							if n["enode_url"] != attrs["enode_url"] || n["tm_public_key"] != attrs["tm_public_key_b64"] || n["p2p_port"] != float64(21000+i) {
+								return fmt.Errorf("unexpected node %v", n)
+							}
+							if n["accounts"].(map[string]interface{})["default"] != attrs["accounts_by_name.default"] {
+								return fmt.Errorf("unexpected accounts %v", n["accounts"])
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config(1),
+				Check: func(s *terraform.State) error {
+					manifest, err := readManifest(s)
+					if err != nil {
+						return err
+					}
+					nodes := manifest["nodes"].(map[string]interface{})
+					if _, ok := nodes["node-0"]; !ok || len(nodes) != 1 {
+						return fmt.Errorf("expect only node-0 but got %v", nodes)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNetwork_whenUnexpectedFiles(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	config := func(forceDestroy bool) string {
+		return fmt.Sprintf(`
+resource "quorum_bootstrap_network" "test" {
+  name          = "test-network"
+  target_dir    = "%s"
+  force_destroy = %t
+}
+`, tempdir, forceDestroy)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: func(_ *terraform.State) error {
+					return ioutil.WriteFile(path.Join(tempdir, "test-network", "unrelated"), []byte{}, 0644)
+				},
+			},
+			{
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`contains unexpected files \[unrelated\]`),
+			},
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("quorum_bootstrap_network.test", "force_destroy", "true"),
+			},
+		},
+		CheckDestroy: func(_ *terraform.State) error {
+			if _, err := os.Stat(path.Join(tempdir, "test-network")); !os.IsNotExist(err) {
+				return fmt.Errorf("expect network directory to be removed")
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccResourceBootstrapNetwork_whenManifestExists(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	existing := newNetworkManifest(path.Join(tempdir, "test-network"), "old-name")
+	if err := os.MkdirAll(path.Join(tempdir, "test-network"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := existing.putNode("node-0", &manifestNode{EnodeURL: "enode://a@127.0.0.1:21000"}, big.NewInt(10), "0x01"); err != nil {
+		t.Fatal(err)
+	}
+	config := func(forceDestroy bool) string {
+		return fmt.Sprintf(`
+resource "quorum_bootstrap_network" "test" {
+  name          = "test-network"
+  target_dir    = "%s"
+  force_destroy = %t
+}
+`, tempdir, forceDestroy)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: func(s *terraform.State) error {
+					var manifest map[string]interface{}
+					raw, err := ioutil.ReadFile(s.RootModule().Resources["quorum_bootstrap_network.test"].Primary.Attributes["manifest_file"])
+					if err != nil {
+						return err
+					}
+					if err := json.Unmarshal(raw, &manifest); err != nil {
+						return err
+					}
+					if manifest["name"] != "test-network" || manifest["genesis_hash"] != "0x01" {
+						return fmt.Errorf("unexpected manifest %v", manifest)
+					}
+					if _, ok := manifest["nodes"].(map[string]interface{})["node-0"]; !ok {
+						return fmt.Errorf("expect node-0 to be kept but got %v", manifest["nodes"])
+					}
+					if _, err := os.Stat(path.Join(tempdir, "test-network", managedDirectoryMarker)); !os.IsNotExist(err) {
+						return fmt.Errorf("expect an existing directory not to be marked")
+					}
+					return nil
+				},
+			},
+			{
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`was not created by this provider`),
+			},
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("quorum_bootstrap_network.test", "force_destroy", "true"),
+			},
+		},
+	})
+}
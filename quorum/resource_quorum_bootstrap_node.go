@@ -0,0 +1,513 @@
+package quorum
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	consensusIstanbul = "istanbul"
+	consensusQbft     = "qbft"
+	consensusRaft     = "raft"
+
+	nodeKeyFileName           = "nodekey"
+	staticNodesFileName       = "static-nodes.json"
+	permissionedNodesFileName = "permissioned-nodes.json"
+)
+
+// Use this resource to create the directory of a single node in a network, wiring together what is otherwise done by
+// `quorum_bootstrap_data_dir`, `quorum_bootstrap_node_key`, `quorum_bootstrap_keystore` and `quorum_transaction_manager_keypair`.
+//
+// The node directory `<network_dir>/<name>` has the following layout which can be used as `--datadir` as is:
+//
+// - `<instance_name>/nodekey`, `<instance_name>/chaindata`, `<instance_name>/lightchaindata` and `<instance_name>/static-nodes.json`
+//
+// - `keystore/` containing the accounts
+//
+// - `tm/tm.key` and `tm/tm.pub` being the transaction manager keypair
+//
+// - `permissioned-nodes.json` when `permissioned` is true
+//
+// When `network_dir` is a directory created by `quorum_bootstrap_network`, the node is listed in its `network.json` manifest.
+//
+// To build `static_nodes` for all nodes in a network without a dependency cycle, create node keys using `quorum_bootstrap_node_key` and pass them via `node_key_hex`.
+func resourceBootstrapNode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBootstrapNodeCreate,
+		ReadContext:   resourceBootstrapNodeRead,
+		UpdateContext: resourceBootstrapNodeUpdate,
+		DeleteContext: resourceBootstrapNodeDelete,
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+				names := make(map[string]bool)
+				for _, raw := range d.Get("account").([]interface{}) {
+					name := raw.(map[string]interface{})["name"].(string)
+					if names[name] {
+						return fmt.Errorf("duplicated account name [%s]", name)
+					}
+					names[name] = true
+				}
+				return nil
+			},
+			customdiff.ComputedIf("accounts_by_name", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				o, n := d.GetChange("account")
+				return !reflect.DeepEqual(toAccountNames(o.([]interface{})), toAccountNames(n.([]interface{})))
+			}),
+		),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_dir": {
+				Type:        schema.TypeString,
+				Description: "Directory of the network in which the node directory is created, e.g.: `network_dir_abs` of `quorum_bootstrap_network`. Relative path is resolved against provider `base_dir`",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "Name of the node directory. Directory name restriction applied",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"instance_name": {
+				Type:        schema.TypeString,
+				Description: "The instance name of the node. This must be the same as the value in geth node config. Default is decided by provider `default_instance_name`",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+			"genesis": {
+				Type:         schema.TypeString,
+				Description:  "Genesis file content in JSON format",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateGenesis,
+			},
+			"consensus": {
+				Type:         schema.TypeString,
+				Description:  "Consensus algorithm of the network which decides the format of the enode URL. Supported: istanbul, qbft and raft. Default is istanbul",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      consensusIstanbul,
+				ValidateFunc: validation.StringInSlice([]string{consensusIstanbul, consensusQbft, consensusRaft}, false),
+			},
+			"node_key_hex": {
+				Type:        schema.TypeString,
+				Description: "Node key as hex, e.g.: from `quorum_bootstrap_node_key`. Default is a newly generated node key",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Description: "Hostname or IP used in the enode URL",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "127.0.0.1",
+			},
+			"p2p_port": {
+				Type:         schema.TypeInt,
+				Description:  "P2P port used in the enode URL",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      21000,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"raft_port": {
+				Type:         schema.TypeInt,
+				Description:  "Raft port used in the enode URL when `consensus` is raft",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      50400,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"use_light_weight_kdf": {
+				Type:        schema.TypeBool,
+				Description: "True to lower the memory and CPU requirements of the key store scrypt KDF at the expense of security. Default is decided by provider `default_keystore_kdf`",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+			"account": {
+				Type:        schema.TypeList,
+				Description: "Account being created in the keystore of the node. Accounts are identified by `name` so they are created, deleted and re-encrypted without affecting the others",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Unique name of the account within the node",
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"passphrase": {
+							Type:        schema.TypeString,
+							Description: "Passphrase to lock/unlock the account. Changing it re-encrypts the existing key. Default is empty",
+							Optional:    true,
+							Default:     "",
+							Sensitive:   true,
+						},
+						"balance": {
+							Type:        schema.TypeString,
+							Description: "A place holder to keep account initial balance for referencing",
+							Optional:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "Address of the newly generated account",
+							Computed:    true,
+						},
+						"account_url": {
+							Type:        schema.TypeString,
+							Description: "Local path to the JSON representation of newly generated account private key",
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"tm_password": {
+				Type:        schema.TypeString,
+				Description: "A password to protect the transaction manager keypair. Argon2 options are decided by provider `default_argon_options`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+				Sensitive:   true,
+			},
+			"static_nodes": {
+				Type:        schema.TypeList,
+				Description: "Enode URLs written to `static-nodes.json`. Default is the enode URL of this node",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"permissioned": {
+				Type:        schema.TypeBool,
+				Description: "True to also write `static-nodes.json` content to `permissioned-nodes.json`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Description: "True to remove the directory on destroy even if it was not created by this provider or contains unexpected files. It must be applied before destroying",
+				Optional:    true,
+				Default:     false,
+			},
+			"network_dir_abs": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to the network directory whose `network.json` manifest lists the node, which is the ID of `quorum_bootstrap_network`",
+				Computed:    true,
+			},
+			"node_dir_abs": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to the node directory. This can be used as `--datadir`",
+				Computed:    true,
+			},
+			"keystore_dir_abs": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to the keystore directory",
+				Computed:    true,
+			},
+			"tm_dir_abs": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to the directory containing `tm.key` and `tm.pub`",
+				Computed:    true,
+			},
+			"genesis_hash": {
+				Type:        schema.TypeString,
+				Description: "Hash of the genesis block written to the databases",
+				Computed:    true,
+			},
+			"hex_node_id": {
+				Type:        schema.TypeString,
+				Description: "64-byte hex value represents node ID which is seen being encoded in the username portion of enode URL",
+				Computed:    true,
+			},
+			"istanbul_address": {
+				Type:        schema.TypeString,
+				Description: "Address representing public key of the node key",
+				Computed:    true,
+			},
+			"enode_url": {
+				Type:        schema.TypeString,
+				Description: "Enode URL of the node",
+				Computed:    true,
+			},
+			"tm_public_key_b64": {
+				Type:        schema.TypeString,
+				Description: "Public key of the transaction manager keypair in standard base64 encoding",
+				Computed:    true,
+			},
+			"accounts_by_name": {
+				Type:        schema.TypeMap,
+				Description: "Addresses of the accounts keyed by account `name`",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceBootstrapNodeCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	if _, ok := d.GetOk("instance_name"); !ok {
+		_ = d.Set("instance_name", config.instanceName)
+	}
+	if _, ok := d.GetOkExists("use_light_weight_kdf"); !ok {
+		_ = d.Set("use_light_weight_kdf", config.useLightWeightKDF)
+	}
+	instanceName := d.Get("instance_name").(string)
+	networkDir, err := filepath.Abs(config.resolvePath(d.Get("network_dir").(string)))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	nodeDir, err := createDirectory(filepath.Join(networkDir, d.Get("name").(string)))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	if files, err := ioutil.ReadDir(nodeDir); err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	} else if len(files) > 0 {
+		return attributeDiag(cty.GetAttrPath("name"), fmt.Errorf("directory [%s] is not empty", nodeDir))
+	}
+	if err := markManagedDirectory(nodeDir, "quorum_bootstrap_node"); err != nil {
+		return attributeDiag(cty.GetAttrPath("name"), err)
+	}
+	d.SetId(nodeDir)
+	_ = d.Set("network_dir_abs", networkDir)
+	_ = d.Set("node_dir_abs", nodeDir)
+
+	// node key
+	nodeKeyHex := d.Get("node_key_hex").(string)
+	if nodeKeyHex == "" {
+		nodeKey, err := crypto.GenerateKey()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		nodeKeyHex = hex.EncodeToString(crypto.FromECDSA(nodeKey))
+		_ = d.Set("node_key_hex", nodeKeyHex)
+	}
+	nodeKey, err := crypto.HexToECDSA(nodeKeyHex)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("node_key_hex"), err)
+	}
+	hexNodeID := fmt.Sprintf("%x", crypto.FromECDSAPub(&nodeKey.PublicKey)[1:])
+	enodeURL := fmt.Sprintf("enode://%s@%s:%d?discport=0", hexNodeID, d.Get("hostname").(string), d.Get("p2p_port").(int))
+	if d.Get("consensus").(string) == consensusRaft {
+		enodeURL = fmt.Sprintf("%s&raftport=%d", enodeURL, d.Get("raft_port").(int))
+	}
+	_ = d.Set("hex_node_id", hexNodeID)
+	_ = d.Set("istanbul_address", strings.ToLower(crypto.PubkeyToAddress(nodeKey.PublicKey).String()))
+	_ = d.Set("enode_url", enodeURL)
+
+	// data dir
+	var genesis *core.Genesis
+	if err := json.Unmarshal([]byte(d.Get("genesis").(string)), &genesis); err != nil {
+		return attributeDiag(cty.GetAttrPath("genesis"), err)
+	}
+	nodeConfig := node.DefaultConfig
+	nodeConfig.DataDir = nodeDir
+	nodeConfig.Name = instanceName
+	config.bootstrapDataDirMux.Lock()
+	genesisHash, diags := setupGenesis(ctx, &nodeConfig, []string{dbChainData, dbLightChainData}, genesis)
+	config.bootstrapDataDirMux.Unlock()
+	if diags.HasError() {
+		return diags
+	}
+	_ = d.Set("genesis_hash", strings.ToLower(genesisHash.Hex()))
+	if err := ioutil.WriteFile(filepath.Join(nodeDir, instanceName, nodeKeyFileName), []byte(nodeKeyHex), 0600); err != nil {
+		return diag.Errorf("can't write node key due to %s", err)
+	}
+
+	// static and permissioned nodes
+	staticNodes := []string{enodeURL}
+	if raw, ok := d.GetOk("static_nodes"); ok {
+		staticNodes = make([]string, 0)
+		for _, v := range raw.([]interface{}) {
+			staticNodes = append(staticNodes, v.(string))
+		}
+	}
+	staticNodesJSON, err := json.MarshalIndent(staticNodes, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(nodeDir, instanceName, staticNodesFileName), staticNodesJSON, 0644); err != nil {
+		return diag.Errorf("can't write %s due to %s", staticNodesFileName, err)
+	}
+	if d.Get("permissioned").(bool) {
+		if err := ioutil.WriteFile(filepath.Join(nodeDir, permissionedNodesFileName), staticNodesJSON, 0644); err != nil {
+			return diag.Errorf("can't write %s due to %s", permissionedNodesFileName, err)
+		}
+	}
+
+	// keystore
+	keystoreDir, err := createDirectory(filepath.Join(nodeDir, "keystore"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("keystore_dir_abs", keystoreDir)
+	ks := newNodeKeyStore(d)
+	newAccounts := d.Get("account").([]interface{})
+	for idx, raw := range newAccounts {
+		if err := createNewAccount(ctx, config, ks, raw); err != nil {
+			return attributeDiag(cty.GetAttrPath("account").IndexInt(idx), err)
+		}
+	}
+	_ = d.Set("account", newAccounts)
+	_ = d.Set("accounts_by_name", toAccountsByName(newAccounts))
+
+	// transaction manager keypair
+	tmDir, err := createDirectory(filepath.Join(nodeDir, "tm"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("tm_dir_abs", tmDir)
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return diag.Errorf("unable to generate keypair due to %s", err)
+	}
+	pubB64 := toStandardBase64EncodedString(pub[:])
+	aOpts := config.argonOpts
+	var privateKeyJSON string
+	err = config.runKDF(ctx, func() (err error) {
+		_, privateKeyJSON, err = toKeyDataJSON(d.Get("tm_password").(string), &aOpts, priv[:], pubB64)
+		return
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmDir, "tm.key"), []byte(privateKeyJSON), 0600); err != nil {
+		return diag.Errorf("can't write transaction manager private key due to %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(tmDir, "tm.pub"), []byte(pubB64), 0644); err != nil {
+		return diag.Errorf("can't write transaction manager public key due to %s", err)
+	}
+	_ = d.Set("tm_public_key_b64", pubB64)
+	log.Println("[DEBUG] Node directory is created", nodeDir)
+
+	manifest, err := lookupNetworkManifest(config, networkDir)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	if manifest != nil {
+		mn := &manifestNode{
+			NodeDir:         nodeDir,
+			EnodeURL:        enodeURL,
+			HexNodeID:       hexNodeID,
+			IstanbulAddress: d.Get("istanbul_address").(string),
+			Hostname:        d.Get("hostname").(string),
+			P2PPort:         d.Get("p2p_port").(int),
+			Accounts:        toManifestAccounts(newAccounts),
+			TmPublicKey:     string(pubB64),
+		}
+		if d.Get("consensus").(string) == consensusRaft {
+			mn.RaftPort = d.Get("raft_port").(int)
+		}
+		if err := manifest.putNode(d.Get("name").(string), mn, genesis.Config.ChainID, strings.ToLower(genesisHash.Hex())); err != nil {
+			return attributeDiag(cty.GetAttrPath("genesis"), err)
+		}
+	}
+	return resourceBootstrapNodeRead(ctx, d, rawConfigurer)
+}
+
+func resourceBootstrapNodeRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceBootstrapNodeUpdate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	// other than account, only force_destroy can be updated and it is used during deletion
+	if !d.HasChange("account") {
+		return nil
+	}
+	config := rawConfigurer.(*configurer)
+	o, n := d.GetChange("account")
+	updatedAccounts, err := updateAccounts(ctx, config, newNodeKeyStore(d), o.([]interface{}), n.([]interface{}))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("account"), err)
+	}
+	_ = d.Set("account", updatedAccounts)
+	_ = d.Set("accounts_by_name", toAccountsByName(updatedAccounts))
+	manifest, err := lookupNetworkManifest(config, d.Get("network_dir_abs").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("network_dir"), err)
+	}
+	if manifest != nil {
+		if err := manifest.setNodeAccounts(d.Get("name").(string), toManifestAccounts(updatedAccounts)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func nodeDirEntries(instanceName string) []string {
+	entries := []string{"keystore/UTC--*", "keystore/.UTC--*.tmp*", "tm/tm.key", "tm/tm.pub", permissionedNodesFileName}
+	for _, name := range []string{nodeKeyFileName, staticNodesFileName, dbChainData, dbLightChainData} {
+		entries = append(entries, path.Join(instanceName, name))
+	}
+	return entries
+}
+
+func newNodeKeyStore(d *schema.ResourceData) *keystore.KeyStore {
+	sn, sp := keystore.StandardScryptN, keystore.StandardScryptP
+	if d.Get("use_light_weight_kdf").(bool) {
+		sn, sp = keystore.LightScryptN, keystore.LightScryptP
+	}
+	return keystore.NewKeyStore(d.Get("keystore_dir_abs").(string), sn, sp)
+}
+
+func toAccountNames(rawAccounts []interface{}) []string {
+	names := make([]string, 0, len(rawAccounts))
+	for _, raw := range rawAccounts {
+		if acc, ok := raw.(map[string]interface{}); ok {
+			names = append(names, fmt.Sprint(acc["name"]))
+		}
+	}
+	return names
+}
+
+func resourceBootstrapNodeDelete(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	dir := d.Get("node_dir_abs").(string)
+	log.Println("[DEBUG] Deleting node directory", dir)
+	if err := removeManagedDirectory(dir, d.Get("force_destroy").(bool), nodeDirEntries(d.Get("instance_name").(string))...); err != nil {
+		return diag.FromErr(err)
+	}
+	manifest, err := lookupNetworkManifest(rawConfigurer.(*configurer), d.Get("network_dir_abs").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if manifest != nil {
+		if err := manifest.removeNode(d.Get("name").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
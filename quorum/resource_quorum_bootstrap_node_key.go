@@ -1,6 +1,7 @@
 package quorum
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -8,7 +9,8 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Use this resource to create a node key for a new Quorum node.
@@ -16,9 +18,9 @@ import (
 // Node key encodes a private key that defines an identity of a Quorum node in the network. It is primarily used in P2P networking.
 func resourceBootstrapNodeKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBootstrapNodeKeyCreate,
-		Read:   resourceBootstrapNodeKeyRead,
-		Delete: resourceBootstrapNodeKeyDelete,
+		CreateContext: resourceBootstrapNodeKeyCreate,
+		ReadContext:   resourceBootstrapNodeKeyRead,
+		DeleteContext: resourceBootstrapNodeKeyDelete,
 
 		Schema: map[string]*schema.Schema{
 			"node_key_hex": {
@@ -46,22 +48,22 @@ func resourceBootstrapNodeKey() *schema.Resource {
 	}
 }
 
-func resourceBootstrapNodeKeyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceBootstrapNodeKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	nodeKey, err := crypto.GenerateKey()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(enode.PubkeyToIDV4(&nodeKey.PublicKey).String())
 	_ = d.Set("node_key_hex", hex.EncodeToString(crypto.FromECDSA(nodeKey)))
-	return resourceBootstrapNodeKeyRead(d, meta)
+	return resourceBootstrapNodeKeyRead(ctx, d, meta)
 }
 
-func resourceBootstrapNodeKeyRead(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapNodeKeyRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	// recover the private key
 	nodeKeyHex := d.Get("node_key_hex").(string)
 	nodeKey, err := crypto.HexToECDSA(nodeKeyHex)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	_ = d.Set("hex_node_id", fmt.Sprintf("%x", crypto.FromECDSAPub(&nodeKey.PublicKey)[1:]))
 	_ = d.Set("node_id", d.Id())
@@ -70,7 +72,7 @@ func resourceBootstrapNodeKeyRead(d *schema.ResourceData, _ interface{}) error {
 	return nil
 }
 
-func resourceBootstrapNodeKeyDelete(d *schema.ResourceData, _ interface{}) error {
+func resourceBootstrapNodeKeyDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	d.SetId("")
 	return nil
 }
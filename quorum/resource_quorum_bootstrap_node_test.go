@@ -0,0 +1,298 @@
+package quorum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// @example
+func TestAccResourceBootstrapNode_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_bootstrap_network" "test" {
+  name       = "my-network"
+  target_dir = "%s"
+}
+
+resource "quorum_bootstrap_node" "test" {
+  network_dir          = quorum_bootstrap_network.test.network_dir_abs
+  name                 = "node-0"
+  use_light_weight_kdf = true
+  genesis = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+
+  account {
+    name    = "alice"
+    balance = "1000000000000000000000000000"
+  }
+
+  account {
+    name       = "bob"
+    passphrase = "secret"
+  }
+}
+`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_node.test", "node_dir_abs", filepath.Join(tempdir, "my-network", "node-0")),
+					resource.TestCheckResourceAttr("quorum_bootstrap_node.test", "keystore_dir_abs", filepath.Join(tempdir, "my-network", "node-0", "keystore")),
+					resource.TestMatchResourceAttr("quorum_bootstrap_node.test", "enode_url", regexp.MustCompile(`^enode://[0-9a-f]{128}@127\.0\.0\.1:21000\?discport=0$`)),
+					resource.TestMatchResourceAttr("quorum_bootstrap_node.test", "genesis_hash", regexp.MustCompile("^0x[0-9a-f]{64}$")),
+					resource.TestMatchResourceAttr("quorum_bootstrap_node.test", "istanbul_address", regexp.MustCompile("^0x[0-9a-f]{40}$")),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_node.test", "tm_public_key_b64"),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_node.test", "accounts_by_name.alice", "quorum_bootstrap_node.test", "account.0.address"),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_node.test", "accounts_by_name.bob", "quorum_bootstrap_node.test", "account.1.address"),
+					func(_ *terraform.State) error {
+						nodeDir := filepath.Join(tempdir, "my-network", "node-0")
+						for _, f := range []string{"geth/nodekey", "geth/chaindata", "geth/lightchaindata", "geth/static-nodes.json", "tm/tm.key", "tm/tm.pub"} {
+							if _, err := os.Stat(filepath.Join(nodeDir, f)); err != nil {
+								return err
+							}
+						}
+						if _, err := os.Stat(filepath.Join(nodeDir, "permissioned-nodes.json")); !os.IsNotExist(err) {
+							return fmt.Errorf("expect no permissioned-nodes.json")
+						}
+						keys, err := ioutil.ReadDir(filepath.Join(nodeDir, "keystore"))
+						if err != nil {
+							return err
+						}
+						if len(keys) != 2 {
+							return fmt.Errorf("expect 2 keys in keystore but got %d", len(keys))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNode_whenRaftWithStaticNodes(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_bootstrap_node_key" "test" {
+  count = 2
+}
+
+locals {
+  enodes = [for i, k in quorum_bootstrap_node_key.test : format("enode://%%s@127.0.0.1:%%d?discport=0&raftport=%%d", k.hex_node_id, 21000 + i, 50400 + i)]
+}
+
+resource "quorum_bootstrap_node" "test" {
+  count        = 2
+  network_dir  = "%s"
+  name         = "node-${count.index}"
+  consensus    = "raft"
+  node_key_hex = quorum_bootstrap_node_key.test[count.index].node_key_hex
+  p2p_port     = 21000 + count.index
+  raft_port    = 50400 + count.index
+  static_nodes = local.enodes
+  permissioned = true
+  genesis = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+}
+`, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_node.test.1", "hex_node_id", "quorum_bootstrap_node_key.test.1", "hex_node_id"),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_node.test.0", "genesis_hash", "quorum_bootstrap_node.test.1", "genesis_hash"),
+					resource.TestMatchResourceAttr("quorum_bootstrap_node.test.1", "enode_url", regexp.MustCompile(`@127\.0\.0\.1:21001\?discport=0&raftport=50401$`)),
+					func(s *terraform.State) error {
+						for i := 0; i < 2; i++ {
+							nodeDir := filepath.Join(tempdir, fmt.Sprintf("node-%d", i))
+							for _, f := range []string{"geth/static-nodes.json", "permissioned-nodes.json"} {
+								raw, err := ioutil.ReadFile(filepath.Join(nodeDir, f))
+								if err != nil {
+									return err
+								}
+								var enodes []string
+								if err := json.Unmarshal(raw, &enodes); err != nil {
+									return err
+								}
+								if len(enodes) != 2 {
+									return fmt.Errorf("expect 2 enodes in %s but got %d", f, len(enodes))
+								}
+								expected := s.RootModule().Resources[fmt.Sprintf("quorum_bootstrap_node.test.%d", i)].Primary.Attributes["enode_url"]
+								if enodes[i] != expected {
+									return fmt.Errorf("expect %s in %s but got %s", expected, f, enodes[i])
+								}
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNode_whenDuplicatedAccountNames(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_bootstrap_node" "test" {
+  network_dir = "ignored"
+  name        = "node-0"
+  genesis     = jsonencode({ config = { chainId = 10 }, alloc = {}, difficulty = "0x00", gasLimit = "0xE0000000" })
+
+  account {
+    name = "alice"
+  }
+
+  account {
+    name = "alice"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`duplicated account name \[alice\]`),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNode_whenAccountsChanged(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	config := func(accounts string) string {
+		return fmt.Sprintf(`
+resource "quorum_bootstrap_node" "test" {
+  network_dir          = "%s"
+  name                 = "node-0"
+  use_light_weight_kdf = true
+  genesis = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+%s
+}
+`, tempdir, accounts)
+	}
+	var nodeID, aliceAddress string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  account {
+    name = "alice"
+  }
+`),
+				Check: func(s *terraform.State) error {
+					attrs := s.RootModule().Resources["quorum_bootstrap_node.test"].Primary.Attributes
+					nodeID, aliceAddress = attrs["hex_node_id"], attrs["accounts_by_name.alice"]
+					return nil
+				},
+			},
+			{
+				Config: config(`
+  account {
+    name       = "alice"
+    passphrase = "secret"
+  }
+
+  account {
+    name = "bob"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("quorum_bootstrap_node.test", "hex_node_id", &nodeID),
+					resource.TestCheckResourceAttrPtr("quorum_bootstrap_node.test", "accounts_by_name.alice", &aliceAddress),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_node.test", "accounts_by_name.bob", "quorum_bootstrap_node.test", "account.1.address"),
+					func(_ *terraform.State) error {
+						keys, err := ioutil.ReadDir(filepath.Join(tempdir, "node-0", "keystore"))
+						if err != nil {
+							return err
+						}
+						if len(keys) != 2 {
+							return fmt.Errorf("expect 2 keys in keystore but got %d", len(keys))
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config(`
+  account {
+    name = "bob"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("quorum_bootstrap_node.test", "hex_node_id", &nodeID),
+					resource.TestCheckNoResourceAttr("quorum_bootstrap_node.test", "accounts_by_name.alice"),
+					func(_ *terraform.State) error {
+						keys, err := ioutil.ReadDir(filepath.Join(tempdir, "node-0", "keystore"))
+						if err != nil {
+							return err
+						}
+						if len(keys) != 1 {
+							return fmt.Errorf("expect 1 key in keystore but got %d", len(keys))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapNode_whenGenesisWithoutChainID(t *testing.T) {
+	for _, genesis := range []string{`"null"`, `jsonencode({ alloc = {}, difficulty = "0x00", gasLimit = "0xE0000000" })`, `jsonencode({ config = { isQuorum = true }, alloc = {}, difficulty = "0x00", gasLimit = "0xE0000000" })`} {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: fmt.Sprintf(`
+resource "quorum_bootstrap_node" "test" {
+  network_dir = "ignored"
+  name        = "node-0"
+  genesis     = %s
+}
+`, genesis),
+					ExpectError: regexp.MustCompile(`config.chainId is required`),
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const permissionConfigFileName = "permission-config.json"
+
+// Use this resource to bootstrap the smart-contract-based permissions model.
+//
+// Permission contracts are deployed and initialized with network admin org, role, accounts and nodes in an in-memory EVM.
+// The resulting contract state is available as `alloc_json` to be merged into the genesis `alloc`
+// and `permission_config_json` is the matching `permission-config.json` content. Nodes then start with the network already booted.
+func resourceBootstrapPermissions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBootstrapPermissionsCreate,
+		ReadContext:   resourceBootstrapPermissionsRead,
+		DeleteContext: resourceBootstrapPermissionsDelete,
+		Schema: map[string]*schema.Schema{
+			"accounts": {
+				Type:        schema.TypeList,
+				Description: "Addresses of network admin accounts",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateAddress,
+				},
+			},
+			"guardian_account": {
+				Type:         schema.TypeString,
+				Description:  "Address of the account which is allowed to upgrade the permissions implementation contract. Default is the first address in `accounts`",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAddress,
+			},
+			"enodes": {
+				Type:        schema.TypeList,
+				Description: "Enode URLs of nodes belonging to the network admin org",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(i interface{}, s string) (ws []string, es []error) {
+						if _, err := enode.ParseV4(i.(string)); err != nil {
+							es = append(es, fmt.Errorf("%s is not a valid enode URL due to %s", s, err))
+						}
+						return
+					},
+				},
+			},
+			"network_admin_org": {
+				Type:        schema.TypeString,
+				Description: "Name of the network admin organization. Default is `ADMINORG`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ADMINORG",
+			},
+			"network_admin_role": {
+				Type:        schema.TypeString,
+				Description: "Name of the network admin role. Default is `ADMIN`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ADMIN",
+			},
+			"org_admin_role": {
+				Type:        schema.TypeString,
+				Description: "Name of the default organization admin role. Default is `ORGADMIN`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "ORGADMIN",
+			},
+			"sub_org_breadth": {
+				Type:        schema.TypeInt,
+				Description: "Maximum number of sub organizations at each level. Default is 4",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     4,
+			},
+			"sub_org_depth": {
+				Type:        schema.TypeInt,
+				Description: "Maximum depth of sub organizations. Default is 4",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     4,
+			},
+			"target_dirs": {
+				Type:        schema.TypeList,
+				Description: "Directories, typically node data dirs, in which `permission-config.json` is written. Relative paths are resolved against provider `base_dir`",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"permission_config_json": {
+				Type:        schema.TypeString,
+				Description: "Content of `permission-config.json`",
+				Computed:    true,
+			},
+			"alloc_json": {
+				Type:        schema.TypeString,
+				Description: "Computed `alloc` in JSON format containing permission contracts to be merged into genesis file",
+				Computed:    true,
+			},
+			"upgradable_address": {
+				Type:        schema.TypeString,
+				Description: "Address of the permissions upgradable contract",
+				Computed:    true,
+			},
+			"interface_address": {
+				Type:        schema.TypeString,
+				Description: "Address of the permissions interface contract",
+				Computed:    true,
+			},
+			"implementation_address": {
+				Type:        schema.TypeString,
+				Description: "Address of the permissions implementation contract",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceBootstrapPermissionsCreate(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	rawAccounts := d.Get("accounts").([]interface{})
+	accounts := make([]common.Address, len(rawAccounts))
+	for idx, raw := range rawAccounts {
+		accounts[idx] = common.HexToAddress(raw.(string))
+	}
+	guardian := accounts[0]
+	if v, ok := d.GetOk("guardian_account"); ok {
+		guardian = common.HexToAddress(v.(string))
+	}
+	rawEnodes := d.Get("enodes").([]interface{})
+	enodes := make([]string, len(rawEnodes))
+	for idx, raw := range rawEnodes {
+		// normalize the same way as geth does when reading static-nodes.json
+		n, err := enode.ParseV4(raw.(string))
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("enodes").IndexInt(idx), err)
+		}
+		enodes[idx] = n.String()
+	}
+	pc, alloc, err := permissionBootstrap(&permissionBootstrapInput{
+		guardian:      guardian,
+		accounts:      accounts,
+		enodes:        enodes,
+		nwAdminOrg:    d.Get("network_admin_org").(string),
+		nwAdminRole:   d.Get("network_admin_role").(string),
+		orgAdminRole:  d.Get("org_admin_role").(string),
+		subOrgBreadth: int64(d.Get("sub_org_breadth").(int)),
+		subOrgDepth:   int64(d.Get("sub_org_depth").(int)),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	configJSON, err := json.MarshalIndent(pc, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	allocJSON, err := json.Marshal(alloc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for idx, raw := range d.Get("target_dirs").([]interface{}) {
+		absDir, err := createDirectory(rawConfigurer.(*configurer).resolvePath(raw.(string)))
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("target_dirs").IndexInt(idx), err)
+		}
+		configFile := path.Join(absDir, permissionConfigFileName)
+		log.Println("[DEBUG] Writing permission config to", configFile)
+		if err := ioutil.WriteFile(configFile, configJSON, 0644); err != nil {
+			return attributeDiag(cty.GetAttrPath("target_dirs").IndexInt(idx), fmt.Errorf("can't write %s due to %s", configFile, err))
+		}
+	}
+	_ = d.Set("permission_config_json", string(configJSON))
+	_ = d.Set("alloc_json", string(allocJSON))
+	_ = d.Set("upgradable_address", strings.ToLower(pc.UpgrdAddress.Hex()))
+	_ = d.Set("interface_address", strings.ToLower(pc.InterfAddress.Hex()))
+	_ = d.Set("implementation_address", strings.ToLower(pc.ImplAddress.Hex()))
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(append(configJSON, allocJSON...))))
+	return nil
+}
+
+func resourceBootstrapPermissionsRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceBootstrapPermissionsDelete(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	for _, raw := range d.Get("target_dirs").([]interface{}) {
+		configFile := path.Join(rawConfigurer.(*configurer).resolvePath(raw.(string)), permissionConfigFileName)
+		if err := os.Remove(configFile); err != nil && !os.IsNotExist(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,67 @@
+package quorum
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// @example
+func TestAccResourceBootstrapPermissions_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	configFile := path.Join(tempdir, "node-0", permissionConfigFileName)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_bootstrap_node_key" "test" {
+						count = 2
+					}
+
+					resource "quorum_bootstrap_keystore" "test" {
+						keystore_dir         = "%s/node-0/keystore"
+						use_light_weight_kdf = true
+						account {
+							name = "admin"
+						}
+					}
+
+					resource "quorum_bootstrap_permissions" "test" {
+						accounts    = quorum_bootstrap_keystore.test.account.*.address
+						enodes      = [for k in quorum_bootstrap_node_key.test : format("enode://%%s@127.0.0.1:21000?discport=0", k.hex_node_id)]
+						target_dirs = ["%s/node-0"]
+					}
+                `, tempdir, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_permissions.test", "permission_config_json"),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_permissions.test", "alloc_json"),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_permissions.test", "upgradable_address"),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_permissions.test", "interface_address"),
+					resource.TestCheckResourceAttrSet("quorum_bootstrap_permissions.test", "implementation_address"),
+					func(s *terraform.State) error {
+						content, err := ioutil.ReadFile(configFile)
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, s.RootModule().Resources["quorum_bootstrap_permissions.test"].Primary.Attributes["permission_config_json"], string(content))
+						return nil
+					},
+				),
+			},
+		},
+	})
+	_, err = os.Stat(configFile)
+	assert.True(t, os.IsNotExist(err))
+}
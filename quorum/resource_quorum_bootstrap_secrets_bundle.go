@@ -0,0 +1,219 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Use this resource to encrypt secret material of a node (node key, transaction manager private key and keystore files)
+// into a bundle for a recipient, so the bundle can be committed or distributed safely and decrypted on the target machine.
+//
+// The bundle is a NaCl sealed box (libsodium `crypto_box_seal`) of a JSON document `{"version":1,"nodeKey":"...","tmPrivateKey":{...},"keystore":{"<file name>":{...}}}`
+// encoded in standard base64. It can be decrypted using the recipient Curve25519 private key, e.g.: `crypto_box_seal_open` in libsodium or `SealedBox` in PyNaCl.
+// A transaction manager keypair can be used as the recipient.
+//
+// Prefer `node_dir` and `keystore_dir` to keep the secrets out of the Terraform state. Their files are read again on refresh
+// and the bundle is recreated when they change.
+func resourceBootstrapSecretsBundle() *schema.Resource {
+	sources := []string{"node_dir", "node_key_hex", "tm_private_key_json", "keystore_dir"}
+	return &schema.Resource{
+		CreateContext: resourceBootstrapSecretsBundleCreate,
+		ReadContext:   resourceBootstrapSecretsBundleRead,
+		DeleteContext: resourceBootstrapSecretsBundleDelete,
+
+		Schema: map[string]*schema.Schema{
+			"recipient_public_key": {
+				Type:         schema.TypeString,
+				Description:  "Curve25519 public key of the recipient in standard base64 encoding, e.g.: `public_key_b64` of `quorum_transaction_manager_keypair`",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsBase64,
+			},
+			"node_dir": {
+				Type:         schema.TypeString,
+				Description:  "Directory created by `quorum_bootstrap_node` from which `nodekey`, `tm/tm.key` and `keystore` files are read. Relative path is resolved against provider `base_dir`",
+				Optional:     true,
+				ForceNew:     true,
+				AtLeastOneOf: sources,
+			},
+			"instance_name": {
+				Type:        schema.TypeString,
+				Description: "The instance name used in `node_dir`. Default is decided by provider `default_instance_name`",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+			"node_key_hex": {
+				Type:          schema.TypeString,
+				Description:   "Node key as hex",
+				Optional:      true,
+				ForceNew:      true,
+				Sensitive:     true,
+				AtLeastOneOf:  sources,
+				ConflictsWith: []string{"node_dir"},
+			},
+			"tm_private_key_json": {
+				Type:          schema.TypeString,
+				Description:   "Transaction manager private key in JSON, e.g.: `private_key_json` of `quorum_transaction_manager_keypair`",
+				Optional:      true,
+				ForceNew:      true,
+				Sensitive:     true,
+				AtLeastOneOf:  sources,
+				ConflictsWith: []string{"node_dir"},
+				ValidateFunc:  validation.StringIsJSON,
+			},
+			"keystore_dir": {
+				Type:          schema.TypeString,
+				Description:   "Keystore directory, e.g.: `keystore_dir_abs` of `quorum_bootstrap_keystore`. Relative path is resolved against provider `base_dir`",
+				Optional:      true,
+				ForceNew:      true,
+				AtLeastOneOf:  sources,
+				ConflictsWith: []string{"node_dir"},
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Description: "File to write the bundle to. Relative path is resolved against provider `base_dir`",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"bundle": {
+				Type:        schema.TypeString,
+				Description: "Encrypted bundle in standard base64 encoding",
+				Computed:    true,
+			},
+			"bundle_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA-256 checksum of `bundle` in hex",
+				Computed:    true,
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA-256 checksum of the secrets being bundled in hex. The bundle is recreated when the files in `node_dir` or `keystore_dir` change",
+				Computed:    true,
+			},
+			"output_file_abs": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to the bundle file",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceBootstrapSecretsBundleCreate(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	if _, ok := d.GetOk("instance_name"); !ok {
+		_ = d.Set("instance_name", config.instanceName)
+	}
+	bundle, diags := readSecretsBundle(config, d)
+	if diags.HasError() {
+		return diags
+	}
+	sourceChecksum, err := bundle.checksum()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sealed, err := sealSecretsBundle(StandardBase64EncodedString(d.Get("recipient_public_key").(string)), bundle)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("recipient_public_key"), err)
+	}
+	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(sealed)))
+	if v, ok := d.GetOk("output_file"); ok {
+		file, err := filepath.Abs(config.resolvePath(v.(string)))
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("output_file"), err)
+		}
+		if err := ioutil.WriteFile(file, []byte(sealed), 0644); err != nil {
+			return attributeDiag(cty.GetAttrPath("output_file"), err)
+		}
+		_ = d.Set("output_file_abs", file)
+	}
+	d.SetId(checksum)
+	_ = d.Set("bundle", string(sealed))
+	_ = d.Set("bundle_sha256", checksum)
+	_ = d.Set("source_sha256", sourceChecksum)
+	return nil
+}
+
+func readSecretsBundle(config *configurer, d *schema.ResourceData) (*secretsBundle, diag.Diagnostics) {
+	bundle := &secretsBundle{Version: 1}
+	nodeKeyHex, tmPrivateKeyJSON, keystoreDir := d.Get("node_key_hex").(string), d.Get("tm_private_key_json").(string), d.Get("keystore_dir").(string)
+	if v, ok := d.GetOk("node_dir"); ok {
+		nodeDir := config.resolvePath(v.(string))
+		nodeKey, err := ioutil.ReadFile(filepath.Join(nodeDir, d.Get("instance_name").(string), nodeKeyFileName))
+		if err != nil {
+			return nil, attributeDiag(cty.GetAttrPath("node_dir"), err)
+		}
+		tmKey, err := ioutil.ReadFile(filepath.Join(nodeDir, "tm", "tm.key"))
+		if err != nil {
+			return nil, attributeDiag(cty.GetAttrPath("node_dir"), err)
+		}
+		nodeKeyHex, tmPrivateKeyJSON, keystoreDir = string(nodeKey), string(tmKey), filepath.Join(nodeDir, "keystore")
+	}
+	bundle.NodeKey = strings.TrimSpace(nodeKeyHex)
+	if tmPrivateKeyJSON != "" {
+		bundle.TmPrivateKey = json.RawMessage(tmPrivateKeyJSON)
+	}
+	if keystoreDir != "" {
+		keyFiles, err := filepath.Glob(filepath.Join(config.resolvePath(keystoreDir), "UTC--*"))
+		if err != nil {
+			return nil, attributeDiag(cty.GetAttrPath("keystore_dir"), err)
+		}
+		bundle.Keystore = make(map[string]json.RawMessage)
+		for _, f := range keyFiles {
+			content, err := ioutil.ReadFile(f)
+			if err != nil {
+				return nil, attributeDiag(cty.GetAttrPath("keystore_dir"), err)
+			}
+			bundle.Keystore[filepath.Base(f)] = json.RawMessage(content)
+		}
+	}
+	return bundle, nil
+}
+
+func resourceBootstrapSecretsBundleRead(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	// secrets read from files are only known at creation so they are read again to detect changes, e.g.: a regenerated node
+	if _, ok := d.GetOk("source_sha256"); ok {
+		bundle, diags := readSecretsBundle(rawConfigurer.(*configurer), d)
+		var checksum string
+		if !diags.HasError() {
+			checksum, _ = bundle.checksum()
+		}
+		if checksum != d.Get("source_sha256").(string) {
+			log.Println("[WARN] Secrets are changed or removed, recreating", d.Id())
+			d.SetId("")
+			return nil
+		}
+	}
+	file := d.Get("output_file_abs").(string)
+	if file == "" {
+		return nil
+	}
+	if checksum, err := fileSHA256(file); err != nil || checksum != d.Get("bundle_sha256").(string) {
+		log.Println("[WARN] Bundle file is changed or removed, recreating", file)
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceBootstrapSecretsBundleDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if file := d.Get("output_file_abs").(string); file != "" {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
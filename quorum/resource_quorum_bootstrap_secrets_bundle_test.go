@@ -0,0 +1,233 @@
+package quorum
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// open the bundle using the private key of the recipient quorum_transaction_manager_keypair
+func openSecretsBundleFromState(s *terraform.State, bundleResource string, recipientResource string) (*secretsBundle, error) {
+	recipient := s.RootModule().Resources[recipientResource].Primary.Attributes
+	var info privateKeyInfo
+	if err := json.Unmarshal([]byte(recipient["private_key_json"]), &info); err != nil {
+		return nil, err
+	}
+	var pub, priv [32]byte
+	pubBytes, err := StandardBase64EncodedString(recipient["public_key_b64"]).bytes()
+	if err != nil {
+		return nil, err
+	}
+	privBytes, err := info.Data.Value.bytes()
+	if err != nil {
+		return nil, err
+	}
+	copy(pub[:], pubBytes)
+	copy(priv[:], privBytes)
+	return openSecretsBundle(StandardBase64EncodedString(s.RootModule().Resources[bundleResource].Primary.Attributes["bundle"]), &pub, &priv)
+}
+
+// @example
+func TestAccResourceBootstrapSecretsBundle_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_bootstrap_node" "test" {
+  network_dir          = "%s"
+  name                 = "node-0"
+  use_light_weight_kdf = true
+  genesis = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+
+  account {
+    name = "default"
+  }
+}
+
+resource "quorum_transaction_manager_keypair" "recipient" {
+}
+
+resource "quorum_bootstrap_secrets_bundle" "test" {
+  recipient_public_key = quorum_transaction_manager_keypair.recipient.public_key_b64
+  node_dir             = quorum_bootstrap_node.test.node_dir_abs
+  output_file          = "%s/node-0.bundle"
+}
+`, tempdir, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_bootstrap_secrets_bundle.test", "output_file_abs", filepath.Join(tempdir, "node-0.bundle")),
+					resource.TestCheckResourceAttrPair("quorum_bootstrap_secrets_bundle.test", "id", "quorum_bootstrap_secrets_bundle.test", "bundle_sha256"),
+					func(s *terraform.State) error {
+						bundle, err := openSecretsBundleFromState(s, "quorum_bootstrap_secrets_bundle.test", "quorum_transaction_manager_keypair.recipient")
+						if err != nil {
+							return err
+						}
+						node := s.RootModule().Resources["quorum_bootstrap_node.test"].Primary.Attributes
+						assert.Equal(t, 1, bundle.Version)
+						assert.Equal(t, node["node_key_hex"], bundle.NodeKey)
+						assert.Contains(t, string(bundle.TmPrivateKey), `"type":"unlocked"`)
+						if assert.Len(t, bundle.Keystore, 1) {
+							for name := range bundle.Keystore {
+								assert.Regexp(t, regexp.MustCompile(node["accounts_by_name.default"][2:]+"$"), name)
+							}
+						}
+						content, err := ioutil.ReadFile(filepath.Join(tempdir, "node-0.bundle"))
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, s.RootModule().Resources["quorum_bootstrap_secrets_bundle.test"].Primary.Attributes["bundle"], string(content))
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapSecretsBundle_whenNodeDirChanged(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	config := fmt.Sprintf(`
+resource "quorum_bootstrap_node" "test" {
+  network_dir          = "%s"
+  name                 = "node-0"
+  use_light_weight_kdf = true
+  genesis = jsonencode({
+    config     = { chainId = 10, isQuorum = true }
+    alloc      = {}
+    difficulty = "0x00"
+    gasLimit   = "0xE0000000"
+  })
+
+  account {
+    name = "default"
+  }
+}
+
+resource "quorum_transaction_manager_keypair" "recipient" {
+}
+
+resource "quorum_bootstrap_secrets_bundle" "test" {
+  recipient_public_key = quorum_transaction_manager_keypair.recipient.public_key_b64
+  node_dir             = quorum_bootstrap_node.test.node_dir_abs
+}
+`, tempdir)
+	var sourceChecksum string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("quorum_bootstrap_secrets_bundle.test", "source_sha256", regexp.MustCompile("^[0-9a-f]{64}$")),
+					func(s *terraform.State) error {
+						sourceChecksum = s.RootModule().Resources["quorum_bootstrap_secrets_bundle.test"].Primary.Attributes["source_sha256"]
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					keyFiles, err := filepath.Glob(filepath.Join(tempdir, "node-0", "keystore", "UTC--*"))
+					if err != nil || len(keyFiles) != 1 {
+						t.Fatalf("expect 1 key file but got %v: %v", keyFiles, err)
+					}
+					content, err := ioutil.ReadFile(keyFiles[0])
+					if err != nil {
+						t.Fatal(err)
+					}
+					if err := ioutil.WriteFile(keyFiles[0]+"-copy", content, 0600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					bundle, err := openSecretsBundleFromState(s, "quorum_bootstrap_secrets_bundle.test", "quorum_transaction_manager_keypair.recipient")
+					if err != nil {
+						return err
+					}
+					assert.Len(t, bundle.Keystore, 2)
+					assert.NotEqual(t, sourceChecksum, s.RootModule().Resources["quorum_bootstrap_secrets_bundle.test"].Primary.Attributes["source_sha256"])
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapSecretsBundle_whenExplicitSecrets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_bootstrap_node_key" "test" {
+}
+
+resource "quorum_transaction_manager_keypair" "test" {
+}
+
+resource "quorum_transaction_manager_keypair" "recipient" {
+}
+
+resource "quorum_bootstrap_secrets_bundle" "test" {
+  recipient_public_key = quorum_transaction_manager_keypair.recipient.public_key_b64
+  node_key_hex         = quorum_bootstrap_node_key.test.node_key_hex
+  tm_private_key_json  = quorum_transaction_manager_keypair.test.private_key_json
+}
+`,
+				Check: func(s *terraform.State) error {
+					bundle, err := openSecretsBundleFromState(s, "quorum_bootstrap_secrets_bundle.test", "quorum_transaction_manager_keypair.recipient")
+					if err != nil {
+						return err
+					}
+					assert.Equal(t, s.RootModule().Resources["quorum_bootstrap_node_key.test"].Primary.Attributes["node_key_hex"], bundle.NodeKey)
+					assert.JSONEq(t, s.RootModule().Resources["quorum_transaction_manager_keypair.test"].Primary.Attributes["private_key_json"], string(bundle.TmPrivateKey))
+					assert.Nil(t, bundle.Keystore)
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccResourceBootstrapSecretsBundle_whenNoSecrets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_bootstrap_secrets_bundle" "test" {
+  recipient_public_key = "QfeDAys9MPDs2XHExtc84jKGHxZg/aj52DTh0vtA3Xc="
+}
+`,
+				ExpectError: regexp.MustCompile("one of `keystore_dir,node_dir,node_key_hex,tm_private_key_json`"),
+			},
+		},
+	})
+}
@@ -0,0 +1,225 @@
+package quorum
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to deploy a contract to a running network from an account in a keystore, e.g. created by `quorum_bootstrap_keystore`.
+//
+// The transaction is signed locally and the resource waits for the receipt. When `private_for` is set, the contract is deployed
+// via a private transaction: the payload is stored in the transaction manager via its third party API (`/storeraw`)
+// and the signed transaction is sent via `eth_sendRawPrivateTransaction`.
+//
+// The resource is recreated if the receipt is no longer found, e.g. the network is recreated.
+// Destroying the resource only removes it from the state as a contract can't be undeployed.
+func resourceContract() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceContractCreate,
+		ReadContext:   resourceContractRead,
+		UpdateContext: resourceContractUpdate,
+		DeleteContext: resourceContractDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of the node the transaction is sent to. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"keystore_dir": {
+				Type:        schema.TypeString,
+				Description: "Keystore directory containing the key of `from`, e.g.: `keystore_dir_abs` of `quorum_bootstrap_keystore`. Relative path is resolved against provider `base_dir`",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"from": {
+				Type:             schema.TypeString,
+				Description:      "Address of the account deploying the contract",
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Description: "Passphrase to decrypt the key of `from`",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"bytecode": {
+				Type:         schema.TypeString,
+				Description:  "Contract creation bytecode as hex",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateHex,
+			},
+			"constructor_args": {
+				Type:         schema.TypeString,
+				Description:  "ABI-encoded constructor arguments as hex being appended to `bytecode`",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateHex,
+			},
+			"gas_limit": {
+				Type:        schema.TypeInt,
+				Description: "Gas limit of the transaction. Default is estimated via `eth_estimateGas`",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"gas_price": {
+				Type:        schema.TypeInt,
+				Description: "Gas price of the transaction. Default is from `eth_gasPrice`",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"private_for": {
+				Type:         schema.TypeList,
+				Description:  "Transaction manager public keys of the participants of the private transaction",
+				Optional:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"tm_third_party_url"},
+			},
+			"private_from": {
+				Type:         schema.TypeString,
+				Description:  "Transaction manager public key of the sender. Default is the default key of the transaction manager",
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"private_for"},
+			},
+			"tm_third_party_url": {
+				Type:         schema.TypeString,
+				Description:  "Third party API URL of the transaction manager paired with the node at `rpc_endpoint`, e.g.: `http://localhost:9080`",
+				Optional:     true,
+				RequiredWith: []string{"private_for"},
+			},
+			"contract_address": {
+				Type:        schema.TypeString,
+				Description: "Address of the deployed contract",
+				Computed:    true,
+			},
+			"transaction_hash": {
+				Type:        schema.TypeString,
+				Description: "Hash of the contract creation transaction",
+				Computed:    true,
+			},
+			"gas_used": {
+				Type:        schema.TypeInt,
+				Description: "Gas used by the transaction",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceContractCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	dep := &contractDeployment{
+		gasLimit:    uint64(d.Get("gas_limit").(int)),
+		privateFrom: d.Get("private_from").(string),
+	}
+	code, err := hexutil.Decode(d.Get("bytecode").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("bytecode"), err)
+	}
+	if v, ok := d.GetOk("constructor_args"); ok {
+		args, err := hexutil.Decode(v.(string))
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("constructor_args"), err)
+		}
+		code = append(code, args...)
+	}
+	dep.code = code
+	if v, ok := d.GetOk("gas_price"); ok {
+		dep.gasPrice = big.NewInt(int64(v.(int)))
+	}
+	for _, k := range d.Get("private_for").([]interface{}) {
+		dep.privateFor = append(dep.privateFor, k.(string))
+	}
+	from := common.HexToAddress(d.Get("from").(string))
+	keyFile, err := findKeyFile(config.resolvePath(d.Get("keystore_dir").(string)), from)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("keystore_dir"), err)
+	}
+	var key *keystore.Key
+	if err := config.runKDF(ctx, func() error {
+		content, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return err
+		}
+		key, err = keystore.DecryptKey(content, d.Get("passphrase").(string))
+		return err
+	}); err != nil {
+		return attributeDiag(cty.GetAttrPath("passphrase"), err)
+	}
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	backend := ethclient.NewClient(client)
+	defer backend.Close()
+	if v, ok := d.GetOk("tm_third_party_url"); ok {
+		if _, err := backend.WithPrivateTransactionManager(v.(string)); err != nil {
+			return attributeDiag(cty.GetAttrPath("tm_third_party_url"), err)
+		}
+	}
+	receipt, err := deployContract(ctx, backend, key.PrivateKey, dep)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Println("[DEBUG] Contract is deployed", receipt.ContractAddress.Hex(), receipt.TxHash.Hex())
+	contractAddress := strings.ToLower(receipt.ContractAddress.Hex())
+	d.SetId(contractAddress)
+	_ = d.Set("contract_address", contractAddress)
+	_ = d.Set("transaction_hash", receipt.TxHash.Hex())
+	_ = d.Set("gas_used", int(receipt.GasUsed))
+	return nil
+}
+
+func resourceContractRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	backend := ethclient.NewClient(client)
+	defer backend.Close()
+	_, err = backend.TransactionReceipt(ctx, common.HexToHash(d.Get("transaction_hash").(string)))
+	if errors.Is(err, ethereum.NotFound) {
+		log.Println("[WARN] Receipt is not found, recreating", d.Get("transaction_hash"))
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("can't get receipt of transaction %s due to %s", d.Get("transaction_hash"), err)
+	}
+	return nil
+}
+
+func resourceContractUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only rpc_endpoint, passphrase and tm_third_party_url can be updated. rpc_endpoint is used in the next read
+	return nil
+}
+
+func resourceContractDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,219 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// start a stub of transaction manager third party API storing raw payloads and return its URL
+func newTestTesseraServer(t *testing.T) (*sync.Map, string) {
+	payloads := new(sync.Map) // hash -> payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Payload string `json:"payload"`
+		}
+		if r.URL.Path != "/storeraw" || json.NewDecoder(r.Body).Decode(&req) != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		payload, err := base64.StdEncoding.DecodeString(req.Payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		hash := sha512.Sum512(payload)
+		payloads.Store(string(hash[:]), payload)
+		_ = json.NewEncoder(w).Encode(map[string]string{"key": base64.StdEncoding.EncodeToString(hash[:])})
+	}))
+	t.Cleanup(srv.Close)
+	return payloads, srv.URL
+}
+
+func testAccCheckContractCode(api *SimulatedEthAPI, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		address := s.RootModule().Resources["quorum_contract.test"].Primary.Attributes["contract_address"]
+		code, err := api.backend.CodeAt(context.Background(), common.HexToAddress(address), nil)
+		if err != nil {
+			return err
+		}
+		if hexutil.Encode(code) != expected {
+			return fmt.Errorf("expected code %s at %s but got %s", expected, address, hexutil.Encode(code))
+		}
+		return nil
+	}
+}
+
+// @example
+func TestAccResourceContract_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	api, endpoint := newTestSimulatedRPCServer(t, core.GenesisAlloc{})
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "quorum" {
+  rpc_endpoint = "%s"
+}
+
+resource "quorum_bootstrap_keystore" "deployer" {
+  keystore_dir         = "%s"
+  use_light_weight_kdf = true
+  account {
+    name       = "deployer"
+    passphrase = "secret"
+  }
+}
+
+resource "quorum_contract" "test" {
+  keystore_dir = quorum_bootstrap_keystore.deployer.keystore_dir_abs
+  from         = quorum_bootstrap_keystore.deployer.accounts_by_name["deployer"]
+  passphrase   = "secret"
+  bytecode     = "0x600a80600c6000396000f3fe602a60005260206000f3"
+}
+`, endpoint, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("quorum_contract.test", "id", "quorum_contract.test", "contract_address"),
+					resource.TestMatchResourceAttr("quorum_contract.test", "contract_address", regexp.MustCompile("^0x[0-9a-f]{40}$")),
+					resource.TestMatchResourceAttr("quorum_contract.test", "transaction_hash", regexp.MustCompile("^0x[0-9a-f]{64}$")),
+					resource.TestCheckResourceAttrSet("quorum_contract.test", "gas_used"),
+					testAccCheckContractCode(api, testContractRuntimeCode),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceContract_whenPrivate(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	api, endpoint := newTestSimulatedRPCServer(t, core.GenesisAlloc{})
+	payloads, tmURL := newTestTesseraServer(t)
+	constructorArgs := "0x000000000000000000000000000000000000000000000000000000000000002a"
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_bootstrap_keystore" "deployer" {
+  keystore_dir         = "%s"
+  use_light_weight_kdf = true
+  account {
+    name = "deployer"
+  }
+}
+
+resource "quorum_contract" "test" {
+  rpc_endpoint       = "%s"
+  keystore_dir       = quorum_bootstrap_keystore.deployer.keystore_dir_abs
+  from               = quorum_bootstrap_keystore.deployer.accounts_by_name["deployer"]
+  bytecode           = "%s"
+  constructor_args   = "%s"
+  gas_limit          = 100000
+  private_for        = ["QfeDAys9MPDs2XHExtc84jKGHxZg/aj52DTh0vtA3Xc="]
+  tm_third_party_url = "%s"
+}
+`, tempdir, endpoint, testContractBytecode, constructorArgs, tmURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("quorum_contract.test", "contract_address", regexp.MustCompile("^0x[0-9a-f]{40}$")),
+					func(_ *terraform.State) error {
+						if !assert.Len(t, api.privateTransactions, 1) {
+							return fmt.Errorf("no private transaction")
+						}
+						ptx := api.privateTransactions[0]
+						assert.Equal(t, []string{"QfeDAys9MPDs2XHExtc84jKGHxZg/aj52DTh0vtA3Xc="}, ptx.privateFor)
+						assert.Equal(t, uint64(100000), ptx.tx.Gas())
+						payload, ok := payloads.Load(string(ptx.tx.Data()))
+						if !assert.True(t, ok, "payload is not stored in the transaction manager") {
+							return fmt.Errorf("no payload")
+						}
+						assert.Equal(t, testContractBytecode+constructorArgs[2:], hexutil.Encode(payload.([]byte)))
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceContract_whenFailed(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	_, endpoint := newTestSimulatedRPCServer(t, core.GenesisAlloc{})
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_bootstrap_keystore" "deployer" {
+  keystore_dir         = "%s"
+  use_light_weight_kdf = true
+  account {
+    name = "deployer"
+  }
+}
+
+resource "quorum_contract" "test" {
+  rpc_endpoint = "%s"
+  keystore_dir = quorum_bootstrap_keystore.deployer.keystore_dir_abs
+  from         = quorum_bootstrap_keystore.deployer.accounts_by_name["deployer"]
+  bytecode     = "0xfe"
+  gas_limit    = 100000
+}
+`, tempdir, endpoint),
+				ExpectError: regexp.MustCompile("transaction 0x[0-9a-f]{64} failed"),
+			},
+		},
+	})
+}
+
+func TestAccResourceContract_whenPrivateWithoutTransactionManager(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_contract" "test" {
+  keystore_dir = "keystore"
+  from         = "0x0638e1574728b6d862dd5d3a3e0942c3be47d996"
+  bytecode     = "0x00"
+  private_for  = ["QfeDAys9MPDs2XHExtc84jKGHxZg/aj52DTh0vtA3Xc="]
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("all of `private_for,tm_third_party_url` must be specified"),
+			},
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package quorum
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to add a validator to a running Istanbul (IBFT or QBFT) network.
+//
+// The address is proposed via `istanbul_propose` on every node in `rpc_endpoints` and the resource waits until
+// `istanbul_getValidators` on every node includes the address. The proposal is then discarded via `istanbul_discard`.
+// A change only takes effect when more than half of the current validators vote for it,
+// hence `rpc_endpoints` must contain enough validators. `istanbul` RPC API must be enabled in the nodes.
+//
+// Destroying the resource proposes the removal of the address the same way.
+// The resource is recreated if the address is removed from the validators outside of Terraform.
+func resourceIstanbulValidator() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIstanbulValidatorCreate,
+		ReadContext:   resourceIstanbulValidatorRead,
+		UpdateContext: resourceIstanbulValidatorUpdate,
+		DeleteContext: resourceIstanbulValidatorDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:             schema.TypeString,
+				Description:      "Istanbul address of the validator, e.g.: `istanbul_address` of `quorum_bootstrap_node`",
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+			},
+			"rpc_endpoints": {
+				Type:        schema.TypeList,
+				Description: "JSON-RPC endpoints of the validators voting for the proposal",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceIstanbulValidatorCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	address := common.HexToAddress(d.Get("address").(string))
+	if diags := proposeIstanbulValidatorFromConfig(ctx, d, rawConfigurer.(*configurer), address, true); diags.HasError() {
+		return diags
+	}
+	d.SetId(strings.ToLower(address.Hex()))
+	return nil
+}
+
+func resourceIstanbulValidatorRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	endpoint := d.Get("rpc_endpoints").([]interface{})[0].(string)
+	client, err := config.dialRPC(ctx, endpoint)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoints").IndexInt(0), err)
+	}
+	defer client.Close()
+	ok, err := isIstanbulValidator(ctx, client, common.HexToAddress(d.Id()))
+	if err != nil {
+		return diag.Errorf("%s on %s", err, endpoint)
+	}
+	if !ok {
+		log.Println("[WARN] Address is no longer a validator, recreating", d.Id())
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceIstanbulValidatorUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only rpc_endpoints can be updated and it is used in the next read or deletion
+	return nil
+}
+
+func resourceIstanbulValidatorDelete(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	if diags := proposeIstanbulValidatorFromConfig(ctx, d, rawConfigurer.(*configurer), common.HexToAddress(d.Id()), false); diags.HasError() {
+		return diags
+	}
+	d.SetId("")
+	return nil
+}
+
+func proposeIstanbulValidatorFromConfig(ctx context.Context, d *schema.ResourceData, config *configurer, address common.Address, auth bool) diag.Diagnostics {
+	endpoints := make([]string, 0)
+	for _, e := range d.Get("rpc_endpoints").([]interface{}) {
+		endpoints = append(endpoints, e.(string))
+	}
+	clients, err := config.dialRPCAll(ctx, endpoints)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoints"), err)
+	}
+	defer closeRPCAll(clients)
+	if err := proposeIstanbulValidator(ctx, clients, endpoints, address, auth, defaultPollInterval); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package quorum
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// istanbulNetworkStub keeps the validators and votes shared by the nodes of a stub network.
+// A vote takes effect as soon as more than half of the validators agree
+type istanbulNetworkStub struct {
+	mux        sync.Mutex
+	validators map[common.Address]bool
+	proposals  []map[common.Address]bool // pending proposals of each node
+}
+
+// IstanbulAPIStub stands in for istanbul RPC API of a node
+type IstanbulAPIStub struct {
+	id  int
+	net *istanbulNetworkStub
+}
+
+func (api *IstanbulAPIStub) Propose(address common.Address, auth bool) {
+	n := api.net
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.proposals[api.id][address] = auth
+	votes := 0
+	for _, p := range n.proposals {
+		if v, ok := p[address]; ok && v == auth {
+			votes++
+		}
+	}
+	if votes*2 > len(n.validators) {
+		if auth {
+			n.validators[address] = true
+		} else {
+			delete(n.validators, address)
+		}
+	}
+}
+
+func (api *IstanbulAPIStub) Discard(address common.Address) {
+	api.net.mux.Lock()
+	defer api.net.mux.Unlock()
+	delete(api.net.proposals[api.id], address)
+}
+
+func (api *IstanbulAPIStub) GetValidators(_ *rpc.BlockNumber) ([]common.Address, error) {
+	api.net.mux.Lock()
+	defer api.net.mux.Unlock()
+	validators := make([]common.Address, 0, len(api.net.validators))
+	for v := range api.net.validators {
+		validators = append(validators, v)
+	}
+	return validators, nil
+}
+
+func (n *istanbulNetworkStub) isValidator(address string) bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.validators[common.HexToAddress(address)]
+}
+
+func (n *istanbulNetworkStub) hasProposals() bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	for _, p := range n.proposals {
+		if len(p) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// start a stub network with the given number of validators and return RPC endpoints of the first nodeCount nodes
+func newTestIstanbulNetwork(t *testing.T, validatorCount int, nodeCount int) (*istanbulNetworkStub, []interface{}) {
+	n := &istanbulNetworkStub{validators: make(map[common.Address]bool)}
+	endpoints := make([]interface{}, 0, nodeCount)
+	for i := 0; i < validatorCount; i++ {
+		n.validators[common.BigToAddress(common.Big1.Lsh(common.Big1, uint(i)))] = true
+		n.proposals = append(n.proposals, make(map[common.Address]bool))
+		if i >= nodeCount {
+			continue
+		}
+		srv := rpc.NewServer()
+		if err := srv.RegisterName("istanbul", &IstanbulAPIStub{id: i, net: n}); err != nil {
+			t.Fatal(err)
+		}
+		httpSrv := httptest.NewServer(srv)
+		t.Cleanup(func() {
+			httpSrv.Close()
+			srv.Stop()
+		})
+		endpoints = append(endpoints, httpSrv.URL)
+	}
+	return n, endpoints
+}
+
+// @example
+func TestAccResourceIstanbulValidator_whenTypical(t *testing.T) {
+	n, endpoints := newTestIstanbulNetwork(t, 4, 3)
+	address := "0x7bd4d2a2c3e8a1b0d6bc9b0b0f5b5e2d3f6a4c91"
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			if n.isValidator(address) {
+				return fmt.Errorf("%s is still a validator", address)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_istanbul_validator" "test" {
+  address       = "0x7bd4d2a2c3e8a1b0d6bc9b0b0f5b5e2d3f6a4c91"
+  rpc_endpoints = ["%s", "%s", "%s"]
+}
+`, endpoints...),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_istanbul_validator.test", "id", address),
+					func(_ *terraform.State) error {
+						if !n.isValidator(address) {
+							return fmt.Errorf("%s is not a validator", address)
+						}
+						if n.hasProposals() {
+							return fmt.Errorf("proposals are not discarded")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+	assert.False(t, n.hasProposals(), "proposals are not discarded after destroy")
+}
+
+func TestAccResourceIstanbulValidator_whenChecksummedAddress(t *testing.T) {
+	_, endpoints := newTestIstanbulNetwork(t, 4, 3)
+	address := "0x7bd4d2a2c3e8a1b0d6bc9b0b0f5b5e2d3f6a4c91"
+	config := func(address string) string {
+		return fmt.Sprintf(`
+resource "quorum_istanbul_validator" "test" {
+  address       = "%s"
+  rpc_endpoints = ["%s", "%s", "%s"]
+}
+`, append([]interface{}{address}, endpoints...)...)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(common.HexToAddress(address).Hex()),
+				Check:  resource.TestCheckResourceAttr("quorum_istanbul_validator.test", "id", address),
+			},
+			{
+				Config:   config(address),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceIstanbulValidator_whenNotEnoughVotes(t *testing.T) {
+	n, endpoints := newTestIstanbulNetwork(t, 4, 2)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_istanbul_validator" "test" {
+  address       = "0x7bd4d2a2c3e8a1b0d6bc9b0b0f5b5e2d3f6a4c91"
+  rpc_endpoints = ["%s", "%s"]
+
+  timeouts {
+    create = "2s"
+  }
+}
+`, endpoints...),
+				ExpectError: regexp.MustCompile("is not in effect"),
+			},
+		},
+	})
+	assert.False(t, n.hasProposals(), "proposals are not discarded after timeout")
+}
+
+func TestAccResourceIstanbulValidator_whenRemovedOutside(t *testing.T) {
+	n, endpoints := newTestIstanbulNetwork(t, 1, 1)
+	address := "0x7bd4d2a2c3e8a1b0d6bc9b0b0f5b5e2d3f6a4c91"
+	config := fmt.Sprintf(`
+resource "quorum_istanbul_validator" "test" {
+  address       = "%s"
+  rpc_endpoints = ["%s"]
+}
+`, append([]interface{}{address}, endpoints...)...)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					n.mux.Lock()
+					defer n.mux.Unlock()
+					delete(n.validators, common.HexToAddress(address))
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to assign an account to an org with a role in a permissioned network.
+//
+// The account is added via `quorumPermission_addAccountToOrg` from an org admin account and the resource waits until `quorumPermission_acctList` includes it.
+// Destroying the resource suspends the account via `quorumPermission_updateAccountStatus` as Quorum does not allow accounts to be removed.
+// For the same reason, creating the resource for an account suspended in the org activates it again and `org_id` can't be changed.
+// Changing `role_id` assigns the role via `quorumPermission_changeAccountRole`.
+// `quorumPermission` RPC API must be enabled in the node at `rpc_endpoint` and `from` must be unlocked.
+//
+// The resource is recreated if the account no longer exists or belongs to another org.
+func resourcePermissionAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePermissionAccountCreate,
+		ReadContext:   resourcePermissionAccountRead,
+		UpdateContext: resourcePermissionAccountUpdate,
+		DeleteContext: resourcePermissionAccountDelete,
+
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if d.Id() != "" && d.HasChange("org_id") {
+				o, n := d.GetChange("org_id")
+				return fmt.Errorf("account can't be moved from org [%s] to [%s] as Quorum does not allow accounts to be removed", o, n)
+			}
+			return nil
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of the node where `from` is unlocked. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"from": {
+				Type:             schema.TypeString,
+				Description:      "Org admin account of the org",
+				Required:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Description: "Full ID of the org, e.g.: `full_org_id` of `quorum_permission_org`. It can't be changed",
+				Required:    true,
+			},
+			"account": {
+				Type:             schema.TypeString,
+				Description:      "Account address",
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+			},
+			"role_id": {
+				Type:        schema.TypeString,
+				Description: "ID of an existing role in the org",
+				Required:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the account: `PendingApproval`, `Active`, `Inactive`, `Suspended`, `Blacklisted`, `Revoked`, `RecoveryInitiated` or `RecoveryCompleted`",
+				Computed:    true,
+			},
+			"is_org_admin": {
+				Type:        schema.TypeBool,
+				Description: "True if the account is an org admin",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePermissionAccountCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	from := common.HexToAddress(d.Get("from").(string))
+	account := common.HexToAddress(d.Get("account").(string))
+	orgID, roleID := d.Get("org_id").(string), d.Get("role_id").(string)
+	existing, err := lookupPermissionAccount(ctx, client, account)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing != nil && existing.OrgId == orgID && existing.Status == types.AcctSuspended {
+		if err := callPermission(ctx, client, "updateAccountStatus", from, orgID, account, permissionActionActivateAccount); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(strings.ToLower(account.Hex()))
+		if existing.RoleId != roleID {
+			if _, err := waitForPermissionAccount(ctx, client, account, "", types.AcctActive); err != nil {
+				return diag.FromErr(err)
+			}
+			if err := callPermission(ctx, client, "changeAccountRole", from, account, orgID, roleID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		if err := callPermission(ctx, client, "addAccountToOrg", from, account, orgID, roleID); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(strings.ToLower(account.Hex()))
+	}
+	acct, err := waitForPermissionAccount(ctx, client, account, roleID, types.AcctPendingApproval, types.AcctActive)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setPermissionAccount(d, acct)
+	return nil
+}
+
+func resourcePermissionAccountRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	acct, err := lookupPermissionAccount(ctx, client, common.HexToAddress(d.Id()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if acct == nil || acct.OrgId != d.Get("org_id").(string) {
+		log.Println("[WARN] Account no longer exists in org", d.Get("org_id"), "recreating")
+		d.SetId("")
+		return nil
+	}
+	setPermissionAccount(d, acct)
+	return nil
+}
+
+func resourcePermissionAccountUpdate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	// other than role_id, only rpc_endpoint and from can be updated and they are used in the next read or deletion
+	if !d.HasChange("role_id") {
+		return nil
+	}
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	from := common.HexToAddress(d.Get("from").(string))
+	account := common.HexToAddress(d.Id())
+	roleID := d.Get("role_id").(string)
+	if err := callPermission(ctx, client, "changeAccountRole", from, account, d.Get("org_id").(string), roleID); err != nil {
+		return attributeDiag(cty.GetAttrPath("role_id"), err)
+	}
+	acct, err := waitForPermissionAccount(ctx, client, account, roleID, types.AcctPendingApproval, types.AcctActive)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setPermissionAccount(d, acct)
+	return nil
+}
+
+func resourcePermissionAccountDelete(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	account := common.HexToAddress(d.Id())
+	acct, err := lookupPermissionAccount(ctx, client, account)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if acct != nil && acct.Status == types.AcctActive {
+		from := common.HexToAddress(d.Get("from").(string))
+		if err := callPermission(ctx, client, "updateAccountStatus", from, acct.OrgId, account, permissionActionSuspendAccount); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := waitForPermissionAccount(ctx, client, account, "", types.AcctSuspended); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
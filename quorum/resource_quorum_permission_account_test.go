@@ -0,0 +1,127 @@
+package quorum
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// @example
+func TestAccResourcePermissionAccount_whenTypical(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			if acct := api.account(testPermissionAccount); acct == nil || acct.Status != types.AcctSuspended {
+				return fmt.Errorf("account is not suspended")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_permission_account" "test" {
+  rpc_endpoint = "%s"
+  from         = "%s"
+  org_id       = "ADMINORG"
+  account      = "%s"
+  role_id      = "MEMBER"
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), testPermissionAccount.Hex()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_permission_account.test", "id", strings.ToLower(testPermissionAccount.Hex())),
+					resource.TestCheckResourceAttr("quorum_permission_account.test", "status", "Active"),
+					resource.TestCheckResourceAttr("quorum_permission_account.test", "is_org_admin", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionAccount_whenChanged(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	checkAccount := func(roleID string) resource.TestCheckFunc {
+		return resource.ComposeAggregateTestCheckFunc(
+			resource.TestCheckResourceAttr("quorum_permission_account.test", "role_id", roleID),
+			resource.TestCheckResourceAttr("quorum_permission_account.test", "status", "Active"),
+			func(_ *terraform.State) error {
+				if acct := api.account(testPermissionAccount); acct == nil || acct.RoleId != roleID || acct.Status != types.AcctActive {
+					return fmt.Errorf("account is not active with role %s: %v", roleID, acct)
+				}
+				return nil
+			},
+		)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPermissionAccountConfig(endpoint, "ADMINORG", "MEMBER"),
+				Check:  checkAccount("MEMBER"),
+			},
+			{
+				Config: testAccPermissionAccountConfig(endpoint, "ADMINORG", "OPERATOR"),
+				Check:  checkAccount("OPERATOR"),
+			},
+			{
+				// addresses are compared case-insensitively
+				Config: strings.NewReplacer(
+					testPermissionNetworkAdmins[0].Hex(), strings.ToLower(testPermissionNetworkAdmins[0].Hex()),
+					testPermissionAccount.Hex(), strings.ToLower(testPermissionAccount.Hex()),
+				).Replace(testAccPermissionAccountConfig(endpoint, "ADMINORG", "OPERATOR")),
+				PlanOnly: true,
+			},
+			{
+				// replacing the account suspends it then activates it again as it can't be added twice
+				Taint:  []string{"quorum_permission_account.test"},
+				Config: testAccPermissionAccountConfig(endpoint, "ADMINORG", "OPERATOR"),
+				Check:  checkAccount("OPERATOR"),
+			},
+			{
+				Config:      testAccPermissionAccountConfig(endpoint, "ADMINORG.SUB1", "OPERATOR"),
+				ExpectError: regexp.MustCompile(`account can't be moved from org \[ADMINORG\] to \[ADMINORG.SUB1\]`),
+			},
+		},
+	})
+}
+
+func testAccPermissionAccountConfig(endpoint string, orgID string, roleID string) string {
+	return fmt.Sprintf(`
+resource "quorum_permission_account" "test" {
+  rpc_endpoint = "%s"
+  from         = "%s"
+  org_id       = "%s"
+  account      = "%s"
+  role_id      = "%s"
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), orgID, testPermissionAccount.Hex(), roleID)
+}
+
+func TestAccResourcePermissionAccount_whenNotOrgAdmin(t *testing.T) {
+	_, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_permission_account" "test" {
+  rpc_endpoint = "%s"
+  from         = "%s"
+  org_id       = "ADMINORG"
+  account      = "%s"
+  role_id      = "MEMBER"
+}
+`, endpoint, testPermissionAccount.Hex(), testPermissionAccount.Hex()),
+				ExpectError: regexp.MustCompile("quorumPermission_addAccountToOrg failed due to account is not org admin of the org"),
+			},
+		},
+	})
+}
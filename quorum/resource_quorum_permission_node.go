@@ -0,0 +1,159 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to add a node to an approved org in a permissioned network.
+//
+// The node is added via `quorumPermission_addNode` from an org admin account and the resource waits until `quorumPermission_nodeList` includes it.
+// Destroying the resource deactivates the node via `quorumPermission_updateNodeStatus` as Quorum does not allow nodes to be removed.
+// For the same reason, creating the resource for a node deactivated in the org activates it again and `org_id` can't be changed.
+// `quorumPermission` RPC API must be enabled in the node at `rpc_endpoint` and `from` must be unlocked.
+//
+// The resource is recreated if the node no longer exists or belongs to another org.
+func resourcePermissionNode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePermissionNodeCreate,
+		ReadContext:   resourcePermissionNodeRead,
+		UpdateContext: resourcePermissionNodeUpdate,
+		DeleteContext: resourcePermissionNodeDelete,
+
+		CustomizeDiff: func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+			if d.Id() != "" && d.HasChange("org_id") {
+				o, n := d.GetChange("org_id")
+				return fmt.Errorf("node can't be moved from org [%s] to [%s] as Quorum does not allow nodes to be removed", o, n)
+			}
+			return nil
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of the node where `from` is unlocked. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"from": {
+				Type:             schema.TypeString,
+				Description:      "Org admin account of the org",
+				Required:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Description: "Full ID of the org, e.g.: `full_org_id` of `quorum_permission_org`. It can't be changed",
+				Required:    true,
+			},
+			"enode_url": {
+				Type:         schema.TypeString,
+				Description:  "Enode URL of the node",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnodeURL,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the node: `PendingApproval`, `Approved`, `Deactivated`, `Blacklisted` or `RecoveryInitiated`",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePermissionNodeCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	enodeURL := d.Get("enode_url").(string)
+	_, nodeID, err := parseEnodeURL(enodeURL)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("enode_url"), err)
+	}
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	from := common.HexToAddress(d.Get("from").(string))
+	orgID := d.Get("org_id").(string)
+	existing, err := lookupPermissionNode(ctx, client, nodeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existing != nil && existing.OrgId == orgID && existing.Status == types.NodeDeactivated {
+		if err := callPermission(ctx, client, "updateNodeStatus", from, orgID, existing.Url, permissionActionActivateNode); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if err := callPermission(ctx, client, "addNode", from, orgID, enodeURL); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(nodeID)
+	node, err := waitForPermissionNode(ctx, client, nodeID, types.NodePendingApproval, types.NodeApproved)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("status", permissionStatusName(permissionNodeStatuses, uint8(node.Status)))
+	return nil
+}
+
+func resourcePermissionNodeRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	node, err := lookupPermissionNode(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if node == nil || node.OrgId != d.Get("org_id").(string) {
+		log.Println("[WARN] Node no longer exists in org", d.Get("org_id"), "recreating")
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("status", permissionStatusName(permissionNodeStatuses, uint8(node.Status)))
+	return nil
+}
+
+func resourcePermissionNodeUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only rpc_endpoint and from can be updated and they are used in the next read or deletion
+	return nil
+}
+
+func resourcePermissionNodeDelete(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	node, err := lookupPermissionNode(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if node != nil && node.Status == types.NodeApproved {
+		from := common.HexToAddress(d.Get("from").(string))
+		if err := callPermission(ctx, client, "updateNodeStatus", from, node.OrgId, node.Url, permissionActionDeactivateNode); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := waitForPermissionNode(ctx, client, d.Id(), types.NodeDeactivated); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
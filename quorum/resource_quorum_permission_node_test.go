@@ -0,0 +1,150 @@
+package quorum
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// @example
+func TestAccResourcePermissionNode_whenTypical(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			if node := api.node(testRaftNodeID); node == nil || node.Status != types.NodeDeactivated {
+				return fmt.Errorf("node is not deactivated")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_permission_node" "test" {
+  rpc_endpoint = "%s"
+  from         = "%s"
+  org_id       = "ADMINORG"
+  enode_url    = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_permission_node.test", "id", testRaftNodeID),
+					resource.TestCheckResourceAttr("quorum_permission_node.test", "status", "Approved"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionNode_whenDeactivatedOutside(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	enodeURL := "enode://" + testRaftNodeID + "@10.0.0.2:21000?discport=0"
+	config := fmt.Sprintf(`
+resource "quorum_permission_node" "test" {
+  rpc_endpoint = "%s"
+  from         = "%s"
+  org_id       = "ADMINORG"
+  enode_url    = "%s"
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), enodeURL)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					if _, err := api.UpdateNodeStatus("ADMINORG", enodeURL, permissionActionDeactivateNode, PermissionTxArgsStub{From: testPermissionNetworkAdmins[0]}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("quorum_permission_node.test", "status", "Deactivated"),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionNode_whenDeactivated(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	config := testAccPermissionNodeConfig(endpoint, "ADMINORG")
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// replacing the node deactivates it then activates it again as it can't be added twice
+				Taint:  []string{"quorum_permission_node.test"},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_permission_node.test", "status", "Approved"),
+					func(_ *terraform.State) error {
+						if node := api.node(testRaftNodeID); node == nil || node.Status != types.NodeApproved {
+							return fmt.Errorf("node is not activated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionNode_whenOrgChanged(t *testing.T) {
+	_, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPermissionNodeConfig(endpoint, "ADMINORG"),
+			},
+			{
+				Config:      testAccPermissionNodeConfig(endpoint, "ADMINORG.SUB1"),
+				ExpectError: regexp.MustCompile(`node can't be moved from org \[ADMINORG\] to \[ADMINORG.SUB1\]`),
+			},
+		},
+	})
+}
+
+func testAccPermissionNodeConfig(endpoint string, orgID string) string {
+	return fmt.Sprintf(`
+resource "quorum_permission_node" "test" {
+  rpc_endpoint = "%s"
+  from         = "%s"
+  org_id       = "%s"
+  enode_url    = "enode://%s@10.0.0.2:21000?discport=0"
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), orgID, testRaftNodeID)
+}
+
+func TestAccResourcePermissionNode_whenInvalidEnodeURL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_permission_node" "test" {
+  rpc_endpoint = "http://localhost:22000"
+  from         = "0xed9d02e382b34818e88b88a309c7fe71e65f419d"
+  org_id       = "ADMINORG"
+  enode_url    = "enode://ac6b@10.0.0.2:21000"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be 128 hex characters"),
+			},
+		},
+	})
+}
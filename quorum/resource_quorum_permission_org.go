@@ -0,0 +1,260 @@
+package quorum
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to onboard an organization to a permissioned network.
+//
+// A top level org is proposed via `quorumPermission_addOrg` from a network admin account then each `approver`
+// approves it via `quorumPermission_approveOrg`. The org is approved once majority of network admins approve it.
+// A sub org is created via `quorumPermission_addSubOrg` from an org admin account of the parent org and needs no approval.
+// `quorumPermission` RPC API must be enabled in the nodes and the sending accounts must be unlocked.
+//
+// Destroying a top level org proposes and approves its suspension as Quorum does not allow orgs to be removed.
+// Creating a top level org which is suspended proposes and approves its reactivation via `quorumPermission_updateOrgStatus`
+// and `quorumPermission_approveOrgStatus` instead. An org pending approval can't be destroyed until it is approved.
+// Destroying a sub org only removes it from the state.
+func resourcePermissionOrg() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePermissionOrgCreate,
+		ReadContext:   resourcePermissionOrgRead,
+		UpdateContext: resourcePermissionOrgUpdate,
+		DeleteContext: resourcePermissionOrgDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of the node where `from` is unlocked. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"from": {
+				Type:             schema.TypeString,
+				Description:      "Network admin account proposing a top level org or org admin account of the parent org creating a sub org",
+				Required:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Description: "ID of the org",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"parent_org_id": {
+				Type:         schema.TypeString,
+				Description:  "Full ID of the parent org to create a sub org",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"parent_org_id", "admin_account"},
+			},
+			"enode_url": {
+				Type:         schema.TypeString,
+				Description:  "Enode URL of the first node of the org",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEnodeURL,
+			},
+			"admin_account": {
+				Type:             schema.TypeString,
+				Description:      "Admin account of a top level org",
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateAddress,
+				DiffSuppressFunc: suppressAddressCaseDiff,
+				ExactlyOneOf:     []string{"parent_org_id", "admin_account"},
+			},
+			"approver": {
+				Type:          schema.TypeList,
+				Description:   "Network admin accounts approving a top level org and its suspension when destroyed. Include `from` if it is to vote as well",
+				Optional:      true,
+				ConflictsWith: []string{"parent_org_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rpc_endpoint": {
+							Type:        schema.TypeString,
+							Description: "JSON-RPC endpoint of the node where the approver is unlocked. Default is the resource `rpc_endpoint`",
+							Optional:    true,
+						},
+						"from": {
+							Type:             schema.TypeString,
+							Description:      "Network admin account",
+							Required:         true,
+							ValidateFunc:     validateAddress,
+							DiffSuppressFunc: suppressAddressCaseDiff,
+						},
+					},
+				},
+			},
+			"wait_for_approval": {
+				Type:        schema.TypeBool,
+				Description: "Wait until the org is approved, or suspended when destroyed. Otherwise the pending `status` is recorded",
+				Optional:    true,
+				Default:     false,
+			},
+			"full_org_id": {
+				Type:        schema.TypeString,
+				Description: "Full ID of the org which is prefixed by the parent org IDs for a sub org",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Description: "Status of the org: `PendingApproval`, `Approved`, `PendingSuspension`, `Suspended` or `RevokeSuspension`",
+				Computed:    true,
+			},
+			"level": {
+				Type:        schema.TypeInt,
+				Description: "Level of the org in the org hierarchy, starting from 1 for a top level org",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePermissionOrgCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	from := common.HexToAddress(d.Get("from").(string))
+	orgID := d.Get("org_id").(string)
+	parentOrgID := d.Get("parent_org_id").(string)
+	enodeURL := d.Get("enode_url").(string)
+	fullOrgID := orgID
+	if parentOrgID != "" {
+		fullOrgID = parentOrgID + "." + orgID
+		if err := callPermission(ctx, client, "addSubOrg", from, parentOrgID, orgID, enodeURL); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(fullOrgID)
+	} else {
+		existing, err := lookupPermissionOrg(ctx, client, fullOrgID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if existing != nil && existing.Status == types.OrgSuspended {
+			if err := callPermission(ctx, client, "updateOrgStatus", from, orgID, permissionActionActivateOrg); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fullOrgID)
+			// approvals are only accepted once the proposal is mined
+			if _, err := waitForPermissionOrg(ctx, client, fullOrgID, permissionOrgRevokeSuspension, types.OrgApproved); err != nil {
+				return diag.FromErr(err)
+			}
+			if diags := approvePermissionOrg(ctx, config, d, "approveOrgStatus", orgID, permissionActionActivateOrg); diags.HasError() {
+				return diags
+			}
+		} else {
+			admin := common.HexToAddress(d.Get("admin_account").(string))
+			if err := callPermission(ctx, client, "addOrg", from, orgID, enodeURL, admin); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fullOrgID)
+			if _, err := waitForPermissionOrg(ctx, client, fullOrgID, types.OrgPendingApproval, types.OrgApproved); err != nil {
+				return diag.FromErr(err)
+			}
+			if diags := approvePermissionOrg(ctx, config, d, "approveOrg", orgID, enodeURL, admin); diags.HasError() {
+				return diags
+			}
+		}
+	}
+	statuses := []types.OrgStatus{types.OrgPendingApproval, permissionOrgRevokeSuspension, types.OrgApproved}
+	if d.Get("wait_for_approval").(bool) {
+		statuses = []types.OrgStatus{types.OrgApproved}
+	}
+	org, err := waitForPermissionOrg(ctx, client, fullOrgID, statuses...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	setPermissionOrg(d, org)
+	return nil
+}
+
+func resourcePermissionOrgRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	org, err := lookupPermissionOrg(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if org == nil {
+		log.Println("[WARN] Org no longer exists, recreating", d.Id())
+		d.SetId("")
+		return nil
+	}
+	setPermissionOrg(d, org)
+	return nil
+}
+
+func resourcePermissionOrgUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only rpc_endpoint, from, approver and wait_for_approval can be updated and they are used in the next read or deletion
+	return nil
+}
+
+func resourcePermissionOrgDelete(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	if d.Get("parent_org_id").(string) != "" {
+		log.Println("[WARN] Sub org can't be suspended, only removing from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	org, err := lookupPermissionOrg(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	status := types.OrgSuspended
+	if org != nil {
+		status = org.Status
+	}
+	switch status {
+	case types.OrgApproved:
+		from := common.HexToAddress(d.Get("from").(string))
+		if err := callPermission(ctx, client, "updateOrgStatus", from, d.Id(), permissionActionSuspendOrg); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := waitForPermissionOrg(ctx, client, d.Id(), types.OrgPendingSuspension, types.OrgSuspended); err != nil {
+			return diag.FromErr(err)
+		}
+		fallthrough
+	case types.OrgPendingSuspension:
+		// a suspension proposed by an earlier destroy is approved as well
+		if diags := approvePermissionOrg(ctx, config, d, "approveOrgStatus", d.Id(), permissionActionSuspendOrg); diags.HasError() {
+			return diags
+		}
+		if d.Get("wait_for_approval").(bool) {
+			if _, err := waitForPermissionOrg(ctx, client, d.Id(), types.OrgSuspended); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	case types.OrgSuspended:
+		// already suspended, e.g. outside of Terraform
+	default:
+		return diag.Errorf("org %s is %s and can't be suspended. Approve it and destroy again, or remove it from the state", d.Id(), permissionStatusName(permissionOrgStatuses, uint8(status)))
+	}
+	d.SetId("")
+	return nil
+}
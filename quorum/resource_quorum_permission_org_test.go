@@ -0,0 +1,229 @@
+package quorum
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// @example
+func TestAccResourcePermissionOrg_whenTypical(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			if org := api.org("ORG1"); org == nil || org.Status != types.OrgSuspended {
+				return fmt.Errorf("org is not suspended")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "quorum" {
+  rpc_endpoint = "%s"
+}
+
+resource "quorum_permission_org" "test" {
+  from              = "%s"
+  org_id            = "ORG1"
+  enode_url         = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+  admin_account     = "%s"
+  wait_for_approval = true
+
+  approver {
+    from = "%s"
+  }
+
+  approver {
+    from = "%s"
+  }
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), testPermissionAccount.Hex(), testPermissionNetworkAdmins[0].Hex(), testPermissionNetworkAdmins[1].Hex()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "id", "ORG1"),
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "full_org_id", "ORG1"),
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "Approved"),
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "level", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionOrg_whenPendingApproval(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	config := fmt.Sprintf(`
+provider "quorum" {
+  rpc_endpoint = "%s"
+}
+
+resource "quorum_permission_org" "test" {
+  from          = "%s"
+  org_id        = "ORG1"
+  enode_url     = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+  admin_account = "%s"
+
+  approver {
+    from = "%s"
+  }
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), testPermissionAccount.Hex(), testPermissionNetworkAdmins[0].Hex())
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "PendingApproval"),
+			},
+			{
+				PreConfig: func() {
+					if _, err := api.ApproveOrg("ORG1", "", testPermissionAccount, PermissionTxArgsStub{From: testPermissionNetworkAdmins[1]}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "Approved"),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionOrg_whenSuspended(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPermissionOrgConfig(endpoint, true),
+			},
+			{
+				// replacing the org suspends it then reactivates it
+				Taint:  []string{"quorum_permission_org.test"},
+				Config: testAccPermissionOrgConfig(endpoint, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "Approved"),
+					func(_ *terraform.State) error {
+						if org := api.org("ORG1"); org == nil || org.Status != types.OrgApproved {
+							return fmt.Errorf("org is not reactivated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionOrg_whenDestroyingPendingApproval(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPermissionOrgConfig(endpoint, false),
+				Check:  resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "PendingApproval"),
+			},
+			{
+				Config:      testAccPermissionOrgConfig(endpoint, false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`org ORG1 is PendingApproval and can't be suspended`),
+			},
+			{
+				PreConfig: func() {
+					if _, err := api.ApproveOrg("ORG1", "", testPermissionAccount, PermissionTxArgsStub{From: testPermissionNetworkAdmins[1]}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccPermissionOrgConfig(endpoint, false),
+				Check:  resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "Approved"),
+			},
+		},
+	})
+}
+
+func testAccPermissionOrgConfig(endpoint string, allApprovers bool) string {
+	approvers := testPermissionNetworkAdmins[:1]
+	if allApprovers {
+		approvers = testPermissionNetworkAdmins
+	}
+	config := fmt.Sprintf(`
+resource "quorum_permission_org" "test" {
+  rpc_endpoint      = "%s"
+  from              = "%s"
+  org_id            = "ORG1"
+  enode_url         = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+  admin_account     = "%s"
+  wait_for_approval = %t
+`, endpoint, testPermissionNetworkAdmins[0].Hex(), testPermissionAccount.Hex(), allApprovers)
+	for _, a := range approvers {
+		config += fmt.Sprintf(`
+  approver {
+    from = "%s"
+  }
+`, a.Hex())
+	}
+	return config + "}\n"
+}
+
+func TestAccResourcePermissionOrg_whenSubOrg(t *testing.T) {
+	api, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			if api.org("ADMINORG.SUB1") == nil {
+				return fmt.Errorf("sub org must not be removed")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_permission_org" "test" {
+  rpc_endpoint  = "%s"
+  from          = "%s"
+  org_id        = "SUB1"
+  parent_org_id = "ADMINORG"
+  enode_url     = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+}
+`, endpoint, testPermissionNetworkAdmins[0].Hex()),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "full_org_id", "ADMINORG.SUB1"),
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "status", "Approved"),
+					resource.TestCheckResourceAttr("quorum_permission_org.test", "level", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourcePermissionOrg_whenNotNetworkAdmin(t *testing.T) {
+	_, endpoint := newTestPermissionNetwork(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_permission_org" "test" {
+  rpc_endpoint  = "%s"
+  from          = "%s"
+  org_id        = "ORG1"
+  enode_url     = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+  admin_account = "%s"
+}
+`, endpoint, testPermissionAccount.Hex(), testPermissionAccount.Hex()),
+				ExpectError: regexp.MustCompile("quorumPermission_addOrg failed due to account is not a network admin"),
+			},
+		},
+	})
+}
@@ -0,0 +1,246 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const pluginSettingsFileName = "plugin-settings.json"
+
+type pluginSettings struct {
+	BaseDir       string                      `json:"baseDir,omitempty"`
+	CentralConfig *pluginCentralConfig        `json:"central,omitempty"`
+	Providers     map[string]pluginDefinition `json:"providers"`
+}
+
+type pluginCentralConfig struct {
+	BaseURL               string `json:"baseURL,omitempty"`
+	CertFingerprint       string `json:"certFingerprint,omitempty"`
+	PublicKeyURI          string `json:"publicKeyURI,omitempty"`
+	InsecureSkipTLSVerify bool   `json:"insecureSkipTLSVerify,omitempty"`
+	PluginDistPath        string `json:"pluginDistPathTemplate,omitempty"`
+	PluginSigPath         string `json:"pluginSigPathTemplate,omitempty"`
+}
+
+type pluginDefinition struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version"`
+	Config  interface{} `json:"config,omitempty"`
+}
+
+// Use this resource to generate `plugin-settings.json` for the GoQuorum plugin framework.
+//
+// The file is written into `data_dir` which is typically `data_dir_abs` of a `quorum_bootstrap_data_dir` resource.
+// Use `--plugins file://<data_dir>/plugin-settings.json` when starting geth.
+func resourcePluginSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePluginSettingsCreate,
+		ReadContext:   resourcePluginSettingsRead,
+		DeleteContext: resourcePluginSettingsDelete,
+		Schema: map[string]*schema.Schema{
+			"data_dir": {
+				Type:        schema.TypeString,
+				Description: "Directory in which `plugin-settings.json` is written. Relative path is resolved against provider `base_dir`. If not set, only `settings_json` is computed",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"base_dir": {
+				Type:        schema.TypeString,
+				Description: "Local directory from where geth reads plugins. Default is `<data_dir>/plugins` as decided by geth",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"central": {
+				Type:        schema.TypeList,
+				Description: "Configuration of the remote plugin central repository",
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"base_url": {
+							Type:        schema.TypeString,
+							Description: "Base URL of the plugin central repository",
+							Required:    true,
+							ValidateFunc: func(i interface{}, s string) (ws []string, es []error) {
+								u, err := url.Parse(i.(string))
+								if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+									es = append(es, fmt.Errorf("%s is not a valid HTTP(S) URL: [%s]", s, i))
+								}
+								return
+							},
+						},
+						"cert_fingerprint": {
+							Type:        schema.TypeString,
+							Description: "Fingerprint of the TLS certificate of the central repository",
+							Optional:    true,
+						},
+						"public_key_uri": {
+							Type:        schema.TypeString,
+							Description: "Path of the PGP public key used to verify plugin signatures, relative to `base_url`",
+							Optional:    true,
+						},
+						"insecure_skip_tls_verify": {
+							Type:        schema.TypeBool,
+							Description: "True to skip TLS verification when connecting to the central repository",
+							Optional:    true,
+						},
+						"plugin_dist_path_template": {
+							Type:        schema.TypeString,
+							Description: "Template of the path to plugin distributions, relative to `base_url`",
+							Optional:    true,
+						},
+						"plugin_sig_path_template": {
+							Type:        schema.TypeString,
+							Description: "Template of the path to plugin signatures, relative to `base_url`",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"plugin": {
+				Type:        schema.TypeList,
+				Description: "Plugin being used by geth",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interface": {
+							Type:         schema.TypeString,
+							Description:  "Plugin interface being implemented. Supported: `helloworld`, `security`, `account` and `qlight`",
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"helloworld", "security", "account", "qlight"}, false),
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the plugin distribution. E.g.: `quorum-plugin-hello-world`",
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"version": {
+							Type:         schema.TypeString,
+							Description:  "Version of the plugin distribution. E.g.: `1.0.0`",
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"config_file": {
+							Type:        schema.TypeString,
+							Description: "Path to the plugin configuration file. Relative path is resolved against provider `base_dir`. Conflicts with `config_content`",
+							Optional:    true,
+						},
+						"config_content": {
+							Type:         schema.TypeString,
+							Description:  "Plugin configuration in JSON format which is embedded in the settings. Conflicts with `config_file`",
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+						},
+					},
+				},
+			},
+			"settings_json": {
+				Type:        schema.TypeString,
+				Description: "Content of `plugin-settings.json`",
+				Computed:    true,
+			},
+			"settings_file": {
+				Type:        schema.TypeString,
+				Description: "Absolute path to the generated `plugin-settings.json`",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePluginSettingsCreate(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	settings := &pluginSettings{
+		BaseDir:   d.Get("base_dir").(string),
+		Providers: make(map[string]pluginDefinition),
+	}
+	if raw, ok := d.GetOk("central"); ok {
+		central := raw.([]interface{})[0].(map[string]interface{})
+		settings.CentralConfig = &pluginCentralConfig{
+			BaseURL:               central["base_url"].(string),
+			CertFingerprint:       central["cert_fingerprint"].(string),
+			PublicKeyURI:          central["public_key_uri"].(string),
+			InsecureSkipTLSVerify: central["insecure_skip_tls_verify"].(bool),
+			PluginDistPath:        central["plugin_dist_path_template"].(string),
+			PluginSigPath:         central["plugin_sig_path_template"].(string),
+		}
+	}
+	for idx, raw := range d.Get("plugin").([]interface{}) {
+		p := raw.(map[string]interface{})
+		iface := p["interface"].(string)
+		if _, ok := settings.Providers[iface]; ok {
+			return attributeDiag(cty.GetAttrPath("plugin").IndexInt(idx).GetAttr("interface"), fmt.Errorf("duplicated interface [%s]", iface))
+		}
+		def := pluginDefinition{
+			Name:    p["name"].(string),
+			Version: p["version"].(string),
+		}
+		configFile, configContent := p["config_file"].(string), p["config_content"].(string)
+		switch {
+		case configFile != "" && configContent != "":
+			return attributeDiag(cty.GetAttrPath("plugin").IndexInt(idx), fmt.Errorf("only one of config_file or config_content can be set"))
+		case configFile != "":
+			absFile, err := filepath.Abs(rawConfigurer.(*configurer).resolvePath(configFile))
+			if err != nil {
+				return attributeDiag(cty.GetAttrPath("plugin").IndexInt(idx).GetAttr("config_file"), fmt.Errorf("can't obtain absolute path due to %s", err))
+			}
+			def.Config = "file://" + absFile
+		case configContent != "":
+			var config interface{}
+			if err := json.Unmarshal([]byte(configContent), &config); err != nil {
+				return attributeDiag(cty.GetAttrPath("plugin").IndexInt(idx).GetAttr("config_content"), fmt.Errorf("invalid config_content due to %s", err))
+			}
+			def.Config = config
+		}
+		settings.Providers[iface] = def
+	}
+	settingsJSON, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if dataDir := d.Get("data_dir").(string); dataDir != "" {
+		absDir, err := createDirectory(rawConfigurer.(*configurer).resolvePath(dataDir))
+		if err != nil {
+			return attributeDiag(cty.GetAttrPath("data_dir"), err)
+		}
+		settingsFile := path.Join(absDir, pluginSettingsFileName)
+		log.Println("[DEBUG] Writing plugin settings to", settingsFile)
+		if err := ioutil.WriteFile(settingsFile, settingsJSON, 0644); err != nil {
+			return attributeDiag(cty.GetAttrPath("data_dir"), fmt.Errorf("can't write %s due to %s", settingsFile, err))
+		}
+		_ = d.Set("settings_file", settingsFile)
+	}
+	_ = d.Set("settings_json", string(settingsJSON))
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(settingsJSON)))
+	return nil
+}
+
+func resourcePluginSettingsRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourcePluginSettingsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	if settingsFile := d.Get("settings_file").(string); settingsFile != "" {
+		if err := os.Remove(settingsFile); err != nil && !os.IsNotExist(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,123 @@
+package quorum
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// @example
+func TestAccResourcePluginSettings_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_plugin_settings" "test" {
+						data_dir = "%s"
+						central {
+							base_url        = "https://artifacts.consensys.net/public/quorum-go-plugins/"
+							public_key_uri  = "/.pgp/Central.pgp.pk"
+						}
+						plugin {
+							interface      = "helloworld"
+							name           = "quorum-plugin-hello-world"
+							version        = "1.0.0"
+							config_content = jsonencode({ language = "en" })
+						}
+					}
+                `, tempdir),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_plugin_settings.test", "settings_file", path.Join(tempdir, pluginSettingsFileName)),
+					resource.TestCheckResourceAttr("quorum_plugin_settings.test", "settings_json", `{
+  "central": {
+    "baseURL": "https://artifacts.consensys.net/public/quorum-go-plugins/",
+    "publicKeyURI": "/.pgp/Central.pgp.pk"
+  },
+  "providers": {
+    "helloworld": {
+      "name": "quorum-plugin-hello-world",
+      "version": "1.0.0",
+      "config": {
+        "language": "en"
+      }
+    }
+  }
+}`),
+				),
+			},
+		},
+	})
+	_, err = os.Stat(path.Join(tempdir, pluginSettingsFileName))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAccResourcePluginSettings_whenDuplicatedInterface(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_plugin_settings" "test" {
+						plugin {
+							interface   = "security"
+							name        = "quorum-security-plugin-enterprise"
+							version     = "0.1.0"
+							config_file = "/tmp/security-config.json"
+						}
+						plugin {
+							interface = "security"
+							name      = "quorum-security-plugin-enterprise"
+							version   = "0.2.0"
+						}
+					}
+                `,
+				ExpectError: regexp.MustCompile("duplicated interface"),
+			},
+		},
+	})
+}
+
+func TestAccResourcePluginSettings_whenRelativeConfigFile(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "quorum" {
+						base_dir = "%s"
+					}
+
+					resource "quorum_plugin_settings" "test" {
+						plugin {
+							interface   = "helloworld"
+							name        = "quorum-plugin-hello-world"
+							version     = "1.0.0"
+							config_file = "plugins/hello-world.json"
+						}
+					}
+                `, tempdir),
+				Check: resource.TestMatchResourceAttr("quorum_plugin_settings.test", "settings_json", regexp.MustCompile(regexp.QuoteMeta(`"config": "file://`+path.Join(tempdir, "plugins", "hello-world.json")+`"`))),
+			},
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Use this resource to add a node to a running Raft cluster.
+//
+// The node is added via `raft_addPeer` and the resource waits until `raft_cluster` includes the node.
+// Destroying the resource removes the node via `raft_removePeer`. `raft` RPC API must be enabled in the node at `rpc_endpoint`,
+// preferably the leader.
+//
+// The resource is recreated if the node is removed from the cluster outside of Terraform.
+func resourceRaftPeer() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRaftPeerCreate,
+		ReadContext:   resourceRaftPeerRead,
+		UpdateContext: resourceRaftPeerUpdate,
+		DeleteContext: resourceRaftPeerDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enode_url": {
+				Type:         schema.TypeString,
+				Description:  "Enode URL of the node including `raftport`, e.g.: `enode_url` of `quorum_bootstrap_node` or `enode://${quorum_bootstrap_node_key.k.hex_node_id}@10.0.0.1:21000?discport=0&raftport=50400`",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRaftEnodeURL,
+			},
+			"rpc_endpoint": {
+				Type:        schema.TypeString,
+				Description: "JSON-RPC endpoint of a node in the cluster, preferably the leader. Default is decided by provider `rpc_endpoint`",
+				Optional:    true,
+			},
+			"raft_id": {
+				Type:        schema.TypeInt,
+				Description: "Raft ID assigned to the node",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRaftPeerCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	enodeURL := d.Get("enode_url").(string)
+	nodeID, err := parseRaftEnodeURL(enodeURL)
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("enode_url"), err)
+	}
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	existingID, err := lookupRaftPeer(ctx, client, nodeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if existingID != 0 {
+		return attributeDiag(cty.GetAttrPath("enode_url"), fmt.Errorf("node is already a member of the cluster with raft ID %d", existingID))
+	}
+	var raftID uint16
+	if err := client.CallContext(ctx, &raftID, "raft_addPeer", enodeURL); err != nil {
+		return diag.Errorf("raft_addPeer failed due to %s", err)
+	}
+	log.Println("[DEBUG] Raft peer is added", raftID, enodeURL)
+	// record the peer before waiting so it is removed when the resource is tainted by a failed wait
+	d.SetId(nodeID)
+	_ = d.Set("raft_id", int(raftID))
+	err = pollUntil(ctx, defaultPollInterval, func() (bool, error) {
+		id, err := lookupRaftPeer(ctx, client, nodeID)
+		return id == raftID, err
+	})
+	if err != nil {
+		return diag.Errorf("raft peer %d is not in the cluster due to %s", raftID, err)
+	}
+	return nil
+}
+
+func resourceRaftPeerRead(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	raftID, err := lookupRaftPeer(ctx, client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if raftID == 0 || int(raftID) != d.Get("raft_id").(int) {
+		log.Println("[WARN] Node is no longer a member of the cluster with raft ID", d.Get("raft_id"), "recreating")
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceRaftPeerUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// only rpc_endpoint can be updated and it is used in the next read or deletion
+	return nil
+}
+
+func resourceRaftPeerDelete(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	config := rawConfigurer.(*configurer)
+	client, err := config.dialRPC(ctx, d.Get("rpc_endpoint").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("rpc_endpoint"), err)
+	}
+	defer client.Close()
+	raftID := uint16(d.Get("raft_id").(int))
+	if err := client.CallContext(ctx, nil, "raft_removePeer", raftID); err != nil {
+		return diag.Errorf("raft_removePeer failed due to %s", err)
+	}
+	err = pollUntil(ctx, defaultPollInterval, func() (bool, error) {
+		id, err := lookupRaftPeer(ctx, client, d.Id())
+		return id != raftID, err
+	})
+	if err != nil {
+		return diag.Errorf("raft peer %d is still in the cluster due to %s", raftID, err)
+	}
+	d.SetId("")
+	return nil
+}
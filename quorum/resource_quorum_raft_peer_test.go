@@ -0,0 +1,238 @@
+package quorum
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// RaftAPIStub stands in for raft RPC API of a cluster member
+type RaftAPIStub struct {
+	mux    sync.Mutex
+	nextID uint16
+	peers  map[uint16]string // raft ID -> node ID
+	// peers added while lagging are not reported by Cluster until lag(false), as if the change is not yet committed
+	lagging bool
+	unseen  map[uint16]bool
+}
+
+func (api *RaftAPIStub) AddPeer(enodeID string) (uint16, error) {
+	u, err := url.Parse(enodeID)
+	if err != nil {
+		return 0, err
+	}
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	for _, nodeID := range api.peers {
+		if nodeID == u.User.Username() {
+			return 0, fmt.Errorf("node with this enode has already been added to the cluster")
+		}
+	}
+	api.nextID++
+	api.peers[api.nextID] = u.User.Username()
+	if api.lagging {
+		api.unseen[api.nextID] = true
+	}
+	return api.nextID, nil
+}
+
+func (api *RaftAPIStub) RemovePeer(raftID uint16) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	delete(api.peers, raftID)
+}
+
+func (api *RaftAPIStub) Cluster() []*raftPeer {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	peers := make([]*raftPeer, 0, len(api.peers))
+	for id, nodeID := range api.peers {
+		if api.unseen[id] {
+			continue
+		}
+		peers = append(peers, &raftPeer{RaftID: id, NodeID: nodeID})
+	}
+	return peers
+}
+
+func (api *RaftAPIStub) lag(lagging bool) {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	api.lagging = lagging
+	if !lagging {
+		api.unseen = make(map[uint16]bool)
+	}
+}
+
+func (api *RaftAPIStub) hasPeer(raftID uint16) bool {
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	_, ok := api.peers[raftID]
+	return ok
+}
+
+// start a stub cluster with a leader whose raft ID is 1 and return its RPC endpoint
+func newTestRaftCluster(t *testing.T) (*RaftAPIStub, string) {
+	api := &RaftAPIStub{nextID: 1, peers: map[uint16]string{1: "f06c06f1d958cb2edf90d8bfb912de287f9b047b4228436e94b5b78e3ee16171d0ba2d2f9f8ab0d4d8e4a1fe07d2d43c0d66e2d3e04a61e2e54ce1b4f5ea2d8f"}, unseen: make(map[uint16]bool)}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("raft", api); err != nil {
+		t.Fatal(err)
+	}
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+	return api, httpSrv.URL
+}
+
+// @example
+func TestAccResourceRaftPeer_whenTypical(t *testing.T) {
+	api, endpoint := newTestRaftCluster(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			if api.hasPeer(2) {
+				return fmt.Errorf("raft peer 2 is still in the cluster")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_raft_peer" "test" {
+  enode_url    = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0&raftport=50400"
+  rpc_endpoint = "%s"
+}
+`, endpoint),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_raft_peer.test", "id", testRaftNodeID),
+					resource.TestCheckResourceAttr("quorum_raft_peer.test", "raft_id", "2"),
+					func(_ *terraform.State) error {
+						if !api.hasPeer(2) {
+							return fmt.Errorf("raft peer 2 is not in the cluster")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceRaftPeer_whenAlreadyMember(t *testing.T) {
+	_, endpoint := newTestRaftCluster(t)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "quorum_raft_peer" "test" {
+  enode_url    = "enode://f06c06f1d958cb2edf90d8bfb912de287f9b047b4228436e94b5b78e3ee16171d0ba2d2f9f8ab0d4d8e4a1fe07d2d43c0d66e2d3e04a61e2e54ce1b4f5ea2d8f@10.0.0.1:21000?discport=0&raftport=50400"
+  rpc_endpoint = "%s"
+}
+`, endpoint),
+				ExpectError: regexp.MustCompile("already a member of the cluster with raft ID 1"),
+			},
+		},
+	})
+}
+
+func TestAccResourceRaftPeer_whenNoRaftPort(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "quorum_raft_peer" "test" {
+  enode_url    = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0"
+  rpc_endpoint = "http://localhost:22000"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("raftport is missing"),
+			},
+		},
+	})
+}
+
+func TestAccResourceRaftPeer_whenRemovedOutside(t *testing.T) {
+	api, endpoint := newTestRaftCluster(t)
+	config := fmt.Sprintf(`
+provider "quorum" {
+  rpc_endpoint = "%s"
+}
+
+resource "quorum_raft_peer" "test" {
+  enode_url = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0&raftport=50400"
+}
+`, endpoint)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					api.RemovePeer(2)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceRaftPeer_whenNotInClusterInTime(t *testing.T) {
+	api, endpoint := newTestRaftCluster(t)
+	api.lag(true)
+	config := fmt.Sprintf(`
+resource "quorum_raft_peer" "test" {
+  enode_url    = "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@10.0.0.2:21000?discport=0&raftport=50400"
+  rpc_endpoint = "%s"
+
+  timeouts {
+    create = "2s"
+  }
+}
+`, endpoint)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("raft peer 2 is not in the cluster"),
+			},
+			{
+				// the tainted peer is removed before adding the node again
+				PreConfig: func() {
+					api.lag(false)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_raft_peer.test", "raft_id", "3"),
+					func(_ *terraform.State) error {
+						if api.hasPeer(2) {
+							return fmt.Errorf("raft peer 2 is still in the cluster")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
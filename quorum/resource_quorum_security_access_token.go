@@ -0,0 +1,170 @@
+package quorum
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Use this resource to mint a signed JWT access token for testing multi-tenancy and the JSON RPC security plugin.
+//
+// Tenant scopes are built from `private_state_identifiers` (`psi://`) and `tm_public_keys` (`private://`)
+// which can be referenced from `quorum_transaction_manager_keypair` resources.
+func resourceSecurityAccessToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecurityAccessTokenCreate,
+		ReadContext:   resourceSecurityAccessTokenRead,
+		DeleteContext: resourceSecurityAccessTokenDelete,
+		Schema: map[string]*schema.Schema{
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Description: "Private key in PEM format to sign the token. E.g.: from `quorum_security_signing_key`",
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Description: "Key ID (`kid`) of the signing key",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"issuer": {
+				Type:         schema.TypeString,
+				Description:  "Issuer (`iss`) of the token. This must be one of the issuers configured in the security plugin",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Description: "Subject (`sub`) of the token",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"audience": {
+				Type:        schema.TypeList,
+				Description: "Audience (`aud`) of the token",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"scopes": {
+				Type:        schema.TypeList,
+				Description: "Additional scopes granted by the token. E.g.: `rpc://eth_*`",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"private_state_identifiers": {
+				Type:        schema.TypeList,
+				Description: "Private state identifiers (tenants) the token has access to. Each adds a `psi://` scope",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tm_public_keys": {
+				Type:        schema.TypeList,
+				Description: "Transaction manager public keys in base64 the token is allowed to use. Each adds a `private://` scope",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"scope_claim": {
+				Type:        schema.TypeString,
+				Description: "Name of the claim containing scopes. This must match `authorization_field` of the security plugin. Default is `scp`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "scp",
+			},
+			"expires_in": {
+				Type:         schema.TypeInt,
+				Description:  "Number of seconds from now after which the token expires. Default is 86400",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      86400,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"scope": {
+				Type:        schema.TypeList,
+				Description: "All scopes granted by the token",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Description: "Expiry time of the token in RFC 3339 format",
+				Computed:    true,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "Signed JWT access token",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func resourceSecurityAccessTokenCreate(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	key, err := decodeSigningKeyPEM(d.Get("private_key_pem").(string))
+	if err != nil {
+		return attributeDiag(cty.GetAttrPath("private_key_pem"), fmt.Errorf("invalid private key due to %s", err))
+	}
+	scopes := make([]string, 0)
+	for _, raw := range d.Get("scopes").([]interface{}) {
+		scopes = append(scopes, raw.(string))
+	}
+	for _, raw := range d.Get("private_state_identifiers").([]interface{}) {
+		scopes = append(scopes, fmt.Sprintf("psi://%s?self.eoa=0x0&node.eoa=0x0", url.PathEscape(raw.(string))))
+	}
+	for _, raw := range d.Get("tm_public_keys").([]interface{}) {
+		scopes = append(scopes, fmt.Sprintf("private://0x0/_/contracts?owned.eoa=0x0&from.tm=%s", url.QueryEscape(raw.(string))))
+	}
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return diag.Errorf("random jti error: %s", err)
+	}
+	now := time.Now().UTC()
+	expiresAt := now.Add(time.Duration(d.Get("expires_in").(int)) * time.Second)
+	claims := map[string]interface{}{
+		"jti":                         hex.EncodeToString(jti),
+		"iss":                         d.Get("issuer").(string),
+		"iat":                         now.Unix(),
+		"nbf":                         now.Unix(),
+		"exp":                         expiresAt.Unix(),
+		d.Get("scope_claim").(string): scopes,
+	}
+	if sub := d.Get("subject").(string); sub != "" {
+		claims["sub"] = sub
+	}
+	if aud := d.Get("audience").([]interface{}); len(aud) > 0 {
+		claims["aud"] = aud
+	}
+	token, err := signJWT(key, d.Get("key_id").(string), claims)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(claims["jti"].(string))
+	_ = d.Set("scope", scopes)
+	_ = d.Set("expires_at", expiresAt.Format(time.RFC3339))
+	_ = d.Set("token", token)
+	return nil
+}
+
+func resourceSecurityAccessTokenRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceSecurityAccessTokenDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
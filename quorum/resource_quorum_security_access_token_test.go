@@ -0,0 +1,78 @@
+package quorum
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// @example
+func TestAccResourceSecurityAccessToken_whenTypical(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_security_signing_key" "test" {
+					}
+
+					resource "quorum_transaction_manager_keypair" "test" {
+					}
+
+					resource "quorum_security_access_token" "test" {
+						private_key_pem           = quorum_security_signing_key.test.private_key_pem
+						key_id                    = quorum_security_signing_key.test.key_id
+						issuer                    = "https://quorum.local/oauth"
+						subject                   = "tenant-1"
+						scopes                    = ["rpc://eth_*", "rpc://rpc_modules"]
+						private_state_identifiers = ["PS1"]
+						tm_public_keys            = [quorum_transaction_manager_keypair.test.public_key_b64]
+					}
+                `,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("quorum_security_access_token.test", "token"),
+					resource.TestCheckResourceAttrSet("quorum_security_access_token.test", "expires_at"),
+					resource.TestCheckResourceAttr("quorum_security_access_token.test", "scope.#", "4"),
+					resource.TestCheckResourceAttr("quorum_security_access_token.test", "scope.2", "psi://PS1?self.eoa=0x0&node.eoa=0x0"),
+					func(s *terraform.State) error {
+						tokenAttrs := s.RootModule().Resources["quorum_security_access_token.test"].Primary.Attributes
+						tmPublicKey := s.RootModule().Resources["quorum_transaction_manager_keypair.test"].Primary.Attributes["public_key_b64"]
+						parts := strings.Split(tokenAttrs["token"], ".")
+						if len(parts) != 3 {
+							return fmt.Errorf("invalid token format")
+						}
+						rawClaims, err := base64.RawURLEncoding.DecodeString(parts[1])
+						if err != nil {
+							return err
+						}
+						var claims struct {
+							Subject string   `json:"sub"`
+							Scopes  []string `json:"scp"`
+						}
+						if err := json.Unmarshal(rawClaims, &claims); err != nil {
+							return err
+						}
+						if claims.Subject != "tenant-1" {
+							return fmt.Errorf("unexpected sub %s", claims.Subject)
+						}
+						privateScope, err := url.Parse(claims.Scopes[3])
+						if err != nil {
+							return err
+						}
+						if actual := privateScope.Query().Get("from.tm"); actual != tmPublicKey {
+							return fmt.Errorf("expected from.tm %s but got %s", tmPublicKey, actual)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,214 @@
+package quorum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type securityPluginConfig struct {
+	TLS             *securityPluginTLSConfig             `json:"tls,omitempty"`
+	TokenValidation *securityPluginTokenValidationConfig `json:"tokenValidation"`
+}
+
+type securityPluginTLSConfig struct {
+	Auto     bool   `json:"auto"`
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+}
+
+type securityPluginTokenValidationConfig struct {
+	Issuers []string                   `json:"issuers"`
+	Cache   *securityPluginCacheConfig `json:"cache,omitempty"`
+	JWS     *securityPluginJWSConfig   `json:"jws"`
+	JWT     *securityPluginJWTConfig   `json:"jwt"`
+}
+
+type securityPluginCacheConfig struct {
+	Limit               int `json:"limit"`
+	ExpirationInSeconds int `json:"expirationInSeconds"`
+}
+
+type securityPluginJWSConfig struct {
+	Endpoint      string                             `json:"endpoint"`
+	TLSConnection *securityPluginTLSConnectionConfig `json:"tlsConnection,omitempty"`
+}
+
+type securityPluginTLSConnectionConfig struct {
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+}
+
+type securityPluginJWTConfig struct {
+	AuthorizationField  string `json:"authorizationField"`
+	PreferIntrospection bool   `json:"preferIntrospection"`
+}
+
+// Use this resource to generate the configuration of the JSON RPC security plugin which validates JWT access tokens.
+//
+// The content can be embedded in `quorum_plugin_settings` via `config_content` for the `security` interface.
+func resourceSecurityPluginConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecurityPluginConfigCreate,
+		ReadContext:   resourceSecurityPluginConfigRead,
+		DeleteContext: resourceSecurityPluginConfigDelete,
+		Schema: map[string]*schema.Schema{
+			"issuers": {
+				Type:        schema.TypeList,
+				Description: "Trusted issuers of access tokens",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"jwks_endpoint": {
+				Type:         schema.TypeString,
+				Description:  "URL from where the JWKS is retrieved to verify token signatures",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"jwks_insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Description: "True to skip TLS verification when retrieving the JWKS",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"authorization_field": {
+				Type:        schema.TypeString,
+				Description: "Name of the claim containing scopes. Default is `scp`",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "scp",
+			},
+			"cache_limit": {
+				Type:        schema.TypeInt,
+				Description: "Maximum number of validated tokens being cached. Default is 80",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     80,
+			},
+			"cache_expiration": {
+				Type:        schema.TypeInt,
+				Description: "Number of seconds a validated token is cached. Default is 3600",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     3600,
+			},
+			"tls": {
+				Type:        schema.TypeList,
+				Description: "TLS configuration of the JSON RPC server. If not set, TLS is disabled",
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auto": {
+							Type:        schema.TypeBool,
+							Description: "True to generate a self-signed certificate",
+							Optional:    true,
+						},
+						"cert_file": {
+							Type:        schema.TypeString,
+							Description: "Path to the certificate file",
+							Optional:    true,
+						},
+						"key_file": {
+							Type:        schema.TypeString,
+							Description: "Path to the private key file",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Description: "Path to a file in which the configuration is written. Relative path is resolved against provider `base_dir`",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"config_json": {
+				Type:        schema.TypeString,
+				Description: "Configuration in JSON format",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSecurityPluginConfigCreate(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	rawIssuers := d.Get("issuers").([]interface{})
+	issuers := make([]string, len(rawIssuers))
+	for idx, raw := range rawIssuers {
+		issuers[idx] = raw.(string)
+	}
+	config := &securityPluginConfig{
+		TokenValidation: &securityPluginTokenValidationConfig{
+			Issuers: issuers,
+			Cache: &securityPluginCacheConfig{
+				Limit:               d.Get("cache_limit").(int),
+				ExpirationInSeconds: d.Get("cache_expiration").(int),
+			},
+			JWS: &securityPluginJWSConfig{
+				Endpoint: d.Get("jwks_endpoint").(string),
+			},
+			JWT: &securityPluginJWTConfig{
+				AuthorizationField: d.Get("authorization_field").(string),
+			},
+		},
+	}
+	if d.Get("jwks_insecure_skip_verify").(bool) {
+		config.TokenValidation.JWS.TLSConnection = &securityPluginTLSConnectionConfig{InsecureSkipVerify: true}
+	}
+	if raw, ok := d.GetOk("tls"); ok {
+		tls := raw.([]interface{})[0].(map[string]interface{})
+		config.TLS = &securityPluginTLSConfig{
+			Auto:     tls["auto"].(bool),
+			CertFile: tls["cert_file"].(string),
+			KeyFile:  tls["key_file"].(string),
+		}
+		if !config.TLS.Auto && (config.TLS.CertFile == "" || config.TLS.KeyFile == "") {
+			return attributeDiag(cty.GetAttrPath("tls").IndexInt(0), fmt.Errorf("cert_file and key_file are required when auto is false"))
+		}
+	}
+	configJSON, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if configFile := d.Get("config_file").(string); configFile != "" {
+		configFile = rawConfigurer.(*configurer).resolvePath(configFile)
+		if _, err := createDirectory(filepath.Dir(configFile)); err != nil {
+			return attributeDiag(cty.GetAttrPath("config_file"), err)
+		}
+		log.Println("[DEBUG] Writing security plugin config to", configFile)
+		if err := ioutil.WriteFile(configFile, configJSON, 0644); err != nil {
+			return attributeDiag(cty.GetAttrPath("config_file"), fmt.Errorf("can't write %s due to %s", configFile, err))
+		}
+	}
+	_ = d.Set("config_json", string(configJSON))
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(configJSON)))
+	return nil
+}
+
+func resourceSecurityPluginConfigRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceSecurityPluginConfigDelete(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	if configFile := d.Get("config_file").(string); configFile != "" {
+		if err := os.Remove(rawConfigurer.(*configurer).resolvePath(configFile)); err != nil && !os.IsNotExist(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
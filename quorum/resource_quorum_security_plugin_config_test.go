@@ -0,0 +1,55 @@
+package quorum
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// @example
+func TestAccResourceSecurityPluginConfig_whenTypical(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "quorum_security_plugin_config" "test" {
+						issuers                   = ["https://quorum.local/oauth"]
+						jwks_endpoint             = "https://localhost:4445/.well-known/jwks.json"
+						jwks_insecure_skip_verify = true
+						tls {
+							auto = true
+						}
+					}
+                `,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("quorum_security_plugin_config.test", "config_json", `{
+  "tls": {
+    "auto": true
+  },
+  "tokenValidation": {
+    "issuers": [
+      "https://quorum.local/oauth"
+    ],
+    "cache": {
+      "limit": 80,
+      "expirationInSeconds": 3600
+    },
+    "jws": {
+      "endpoint": "https://localhost:4445/.well-known/jwks.json",
+      "tlsConnection": {
+        "insecureSkipVerify": true
+      }
+    },
+    "jwt": {
+      "authorizationField": "scp",
+      "preferIntrospection": false
+    }
+  }
+}`),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,132 @@
+package quorum
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Use this resource to create a key which signs JWT access tokens for testing multi-tenancy and the JSON RPC security plugin.
+//
+// The public key is published as JSON Web Key Set (JWKS) which the security plugin uses to verify tokens.
+func resourceSecuritySigningKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecuritySigningKeyCreate,
+		ReadContext:   resourceSecuritySigningKeyRead,
+		DeleteContext: resourceSecuritySigningKeyDelete,
+		Schema: map[string]*schema.Schema{
+			"algorithm": {
+				Type:         schema.TypeString,
+				Description:  "JWS algorithm of the key. Supported: `RS256` and `ES256`. Default is `RS256`",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      jwtAlgorithmRS256,
+				ValidateFunc: validation.StringInSlice([]string{jwtAlgorithmRS256, jwtAlgorithmES256}, false),
+			},
+			"rsa_bits": {
+				Type:         schema.TypeInt,
+				Description:  "Size of the RSA key in bits. Only applicable for `RS256`. Default is 2048",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      2048,
+				ValidateFunc: validation.IntAtLeast(2048),
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Description: "Key ID (`kid`) of the key. Default is the RFC 7638 JWK thumbprint",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+			"jwks_file": {
+				Type:        schema.TypeString,
+				Description: "Path to a file in which the JWKS is written. Relative path is resolved against provider `base_dir`",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Description: "Private key in PEM format",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"public_key_pem": {
+				Type:        schema.TypeString,
+				Description: "Public key in PEM format",
+				Computed:    true,
+			},
+			"jwks_json": {
+				Type:        schema.TypeString,
+				Description: "JSON Web Key Set containing the public key",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSecuritySigningKeyCreate(ctx context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	var key crypto.Signer
+	err := runWithContext(ctx, func() (err error) {
+		key, err = generateSigningKey(d.Get("algorithm").(string), d.Get("rsa_bits").(int))
+		return
+	})
+	if err != nil {
+		return diag.Errorf("unable to generate signing key due to %s", err)
+	}
+	jwk, err := toJSONWebKey(key, d.Get("key_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	jwksJSON, err := json.MarshalIndent(&jsonWebKeySet{Keys: []*jsonWebKey{jwk}}, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	privateKeyPEM, err := encodeSigningKeyPEM(key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	publicKeyPEM, err := encodePublicKeyPEM(key)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if jwksFile := d.Get("jwks_file").(string); jwksFile != "" {
+		jwksFile = rawConfigurer.(*configurer).resolvePath(jwksFile)
+		if _, err := createDirectory(filepath.Dir(jwksFile)); err != nil {
+			return attributeDiag(cty.GetAttrPath("jwks_file"), err)
+		}
+		log.Println("[DEBUG] Writing JWKS to", jwksFile)
+		if err := ioutil.WriteFile(jwksFile, jwksJSON, 0644); err != nil {
+			return attributeDiag(cty.GetAttrPath("jwks_file"), fmt.Errorf("can't write %s due to %s", jwksFile, err))
+		}
+	}
+	d.SetId(jwk.Kid)
+	_ = d.Set("key_id", jwk.Kid)
+	_ = d.Set("private_key_pem", privateKeyPEM)
+	_ = d.Set("public_key_pem", publicKeyPEM)
+	_ = d.Set("jwks_json", string(jwksJSON))
+	return nil
+}
+
+func resourceSecuritySigningKeyRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceSecuritySigningKeyDelete(_ context.Context, d *schema.ResourceData, rawConfigurer interface{}) diag.Diagnostics {
+	if jwksFile := d.Get("jwks_file").(string); jwksFile != "" {
+		if err := os.Remove(rawConfigurer.(*configurer).resolvePath(jwksFile)); err != nil && !os.IsNotExist(err) {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,88 @@
+package quorum
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// @example
+func TestAccResourceSecuritySigningKey_whenTypical(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	jwksFile := path.Join(tempdir, "jwks.json")
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "quorum_security_signing_key" "test" {
+						algorithm = "ES256"
+						jwks_file = "%s"
+					}
+                `, jwksFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("quorum_security_signing_key.test", "key_id"),
+					resource.TestCheckResourceAttrSet("quorum_security_signing_key.test", "private_key_pem"),
+					resource.TestCheckResourceAttrSet("quorum_security_signing_key.test", "public_key_pem"),
+					resource.TestCheckResourceAttrSet("quorum_security_signing_key.test", "jwks_json"),
+					resource.TestCheckResourceAttrPair("quorum_security_signing_key.test", "id", "quorum_security_signing_key.test", "key_id"),
+				),
+			},
+		},
+	})
+	_, err = os.Stat(jwksFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestAccResourceSecuritySigningKey_whenRelativeFiles(t *testing.T) {
+	tempdir, err := ioutil.TempDir("", "testacc-")
+	if err != nil {
+		t.Fatalf("can't create temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempdir)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		CheckDestroy: func(_ *terraform.State) error {
+			assert.NoFileExists(t, path.Join(tempdir, "security", "jwks.json"))
+			assert.NoFileExists(t, path.Join(tempdir, "security", "config.json"))
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "quorum" {
+						base_dir = "%s"
+					}
+
+					resource "quorum_security_signing_key" "test" {
+						algorithm = "ES256"
+						jwks_file = "security/jwks.json"
+					}
+
+					resource "quorum_security_plugin_config" "test" {
+						issuers       = ["https://quorum.local/oauth"]
+						jwks_endpoint = "https://localhost:4445/.well-known/jwks.json"
+						config_file   = "security/config.json"
+					}
+                `, tempdir),
+				Check: func(_ *terraform.State) error {
+					assert.FileExists(t, path.Join(tempdir, "security", "jwks.json"))
+					assert.FileExists(t, path.Join(tempdir, "security", "config.json"))
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -1,10 +1,13 @@
 package quorum
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/crypto/nacl/box"
 )
 
@@ -13,9 +16,13 @@ import (
 // This key pair provides attributes which are useful when building the configuration for a transaction manager.
 func resourceTransactionManagerKeyPair() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTransactionManagerKeyPairCreate,
-		Read:   resourceTransactionManagerKeyPairRead,
-		Delete: resourceTransactionManagerKeyPairDelete,
+		CreateContext: resourceTransactionManagerKeyPairCreate,
+		ReadContext:   resourceTransactionManagerKeyPairRead,
+		DeleteContext: resourceTransactionManagerKeyPairDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"password": {
@@ -36,9 +43,9 @@ func resourceTransactionManagerKeyPair() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"variant": {
 							Type:        schema.TypeString,
-							Description: "Algorithm to use when hashing. Allowed values are `id` or `i`",
-							Default:     defaultArgonOpts.Algorithm,
+							Description: "Algorithm to use when hashing. Allowed values are `id` or `i`. Default is decided by provider `default_argon_options`",
 							Optional:    true,
+							Computed:    true,
 							ValidateFunc: func(v interface{}, s string) (strings []string, errors []error) {
 								value := v.(string)
 								if value == "id" || value == "i" {
@@ -50,21 +57,21 @@ func resourceTransactionManagerKeyPair() *schema.Resource {
 						},
 						"iterations": {
 							Type:        schema.TypeInt,
-							Description: "Number of iterations to cycle through",
-							Default:     defaultArgonOpts.Iterations,
+							Description: "Number of iterations to cycle through. Default is decided by provider `default_argon_options`",
 							Optional:    true,
+							Computed:    true,
 						},
 						"memory": {
 							Type:        schema.TypeInt,
-							Description: "Memory limit",
-							Default:     defaultArgonOpts.Memory,
+							Description: "Memory limit. Default is decided by provider `default_argon_options`",
 							Optional:    true,
+							Computed:    true,
 						},
 						"parallelism": {
 							Type:        schema.TypeInt,
-							Description: "Number of threads to use",
-							Default:     defaultArgonOpts.Parallelism,
+							Description: "Number of threads to use. Default is decided by provider `default_argon_options`",
 							Optional:    true,
+							Computed:    true,
 						},
 					},
 				},
@@ -90,41 +97,59 @@ func resourceTransactionManagerKeyPair() *schema.Resource {
 	}
 }
 
-func resourceTransactionManagerKeyPairCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceTransactionManagerKeyPairCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	pub, priv, err := box.GenerateKey(rand.Reader)
 	if err != nil {
-		return fmt.Errorf("unable to generate keypair due to %s", err)
+		return diag.Errorf("unable to generate keypair due to %s", err)
 	}
 	pubB64 := toStandardBase64EncodedString(pub[:])
 	d.SetId(string(pubB64))
 	_ = d.Set("public_key_b64", pubB64)
-	keyDataJSON, privateKeyJSON, err := toKeyDataJSON(d.Get("password").(string), toArgonOptions(d), priv[:], pubB64)
+	aOpts := toArgonOptions(d, meta.(*configurer).argonOpts)
+	var keyDataJSON, privateKeyJSON string
+	err = meta.(*configurer).runKDF(ctx, func() (err error) {
+		keyDataJSON, privateKeyJSON, err = toKeyDataJSON(d.Get("password").(string), aOpts, priv[:], pubB64)
+		return
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	_ = d.Set("key_data", keyDataJSON)
 	_ = d.Set("private_key_json", privateKeyJSON)
-	return resourceTransactionManagerKeyPairRead(d, meta)
+	return resourceTransactionManagerKeyPairRead(ctx, d, meta)
 }
 
-func toArgonOptions(d *schema.ResourceData) *argonOptions {
+func toArgonOptions(d *schema.ResourceData, defaults argonOptions) *argonOptions {
+	opts := defaults
 	if cfg, ok := d.GetOk("config"); ok {
-		rawOpts := cfg.([]interface{})[0].(map[string]interface{})
-		return &argonOptions{
-			Algorithm:   rawOpts["variant"].(string),
-			Iterations:  rawOpts["iterations"].(int),
-			Memory:      rawOpts["memory"].(int),
-			Parallelism: rawOpts["parallelism"].(int),
+		rawOpts, _ := cfg.([]interface{})[0].(map[string]interface{})
+		if v, ok := rawOpts["variant"].(string); ok && v != "" {
+			opts.Algorithm = v
+		}
+		if v, ok := rawOpts["iterations"].(int); ok && v > 0 {
+			opts.Iterations = v
 		}
+		if v, ok := rawOpts["memory"].(int); ok && v > 0 {
+			opts.Memory = v
+		}
+		if v, ok := rawOpts["parallelism"].(int); ok && v > 0 {
+			opts.Parallelism = v
+		}
+		_ = d.Set("config", []interface{}{map[string]interface{}{
+			"variant":     opts.Algorithm,
+			"iterations":  opts.Iterations,
+			"memory":      opts.Memory,
+			"parallelism": opts.Parallelism,
+		}})
 	}
-	return nil
+	return &opts
 }
 
-func resourceTransactionManagerKeyPairRead(d *schema.ResourceData, _ interface{}) error {
+func resourceTransactionManagerKeyPairRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourceTransactionManagerKeyPairDelete(d *schema.ResourceData, _ interface{}) error {
+func resourceTransactionManagerKeyPairDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	d.SetId("")
 	return nil
 }
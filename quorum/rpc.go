@@ -0,0 +1,95 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// interval between checks when waiting for a change to take effect in a running network
+const defaultPollInterval = time.Second
+
+// toBlockNumberArg converts a block tag (latest, earliest or pending), a hex or a decimal block number
+// to the block parameter of JSON-RPC calls
+func toBlockNumberArg(block string) (string, error) {
+	switch {
+	case block == "latest" || block == "earliest" || block == "pending":
+		return block, nil
+	case strings.HasPrefix(block, "0x"):
+		n, err := hexutil.DecodeUint64(block)
+		if err != nil {
+			return "", fmt.Errorf("invalid block number [%s] due to %s", block, err)
+		}
+		return hexutil.EncodeUint64(n), nil
+	default:
+		n, err := strconv.ParseUint(block, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid block [%s], expect latest, earliest, pending or a block number", block)
+		}
+		return hexutil.EncodeUint64(n), nil
+	}
+}
+
+func validateBlockNumber(i interface{}, s string) (ws []string, es []error) {
+	if _, err := toBlockNumberArg(i.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s is not valid due to %s", s, err))
+	}
+	return
+}
+
+// dial the JSON-RPC endpoint. Provider rpc_endpoint is used if the given endpoint is empty
+func (c *configurer) dialRPC(ctx context.Context, endpoint string) (*rpc.Client, error) {
+	if endpoint == "" {
+		endpoint = c.rpcEndpoint
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("rpc_endpoint is required either in the provider or in the data source/resource")
+	}
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to %s due to %s", endpoint, err)
+	}
+	return client, nil
+}
+
+// dial all endpoints. Clients are returned in the same order as the endpoints and must be closed by closeRPCAll
+func (c *configurer) dialRPCAll(ctx context.Context, endpoints []string) ([]*rpc.Client, error) {
+	clients := make([]*rpc.Client, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		client, err := c.dialRPC(ctx, endpoint)
+		if err != nil {
+			closeRPCAll(clients)
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func closeRPCAll(clients []*rpc.Client) {
+	for _, client := range clients {
+		client.Close()
+	}
+}
+
+// call check every interval until it returns true or an error, or ctx is done
+func pollUntil(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
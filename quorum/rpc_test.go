@@ -0,0 +1,246 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// stand-ins for the node RPC APIs, exported as required by the rpc package
+type AdminAPIStub struct{}
+
+func (AdminAPIStub) NodeInfo() *p2p.NodeInfo {
+	info := &p2p.NodeInfo{
+		ID:         "b5ac0ba1e1fa2f2a8fbc6bd2b9dd1c97b1f6f6b4d07d4fa0fc8e4a0f2b1c3d4e",
+		Name:       "Geth/v1.9.7-stable/linux-amd64/go1.15",
+		Enode:      "enode://ac6b1096ca56b9f6d004b779ae3728bf83f8e22453404cc3cef16a3d9b96608bc67c4b30db88e0a5a6c6390213f7acbe1153ff6d23ce57380104288ae19373ef@127.0.0.1:21000?discport=0",
+		IP:         "127.0.0.1",
+		ListenAddr: "[::]:21000",
+		Protocols:  map[string]interface{}{"istanbul": map[string]interface{}{"network": 10}},
+	}
+	info.Ports.Listener = 21000
+	return info
+}
+
+type NetAPIStub struct{}
+
+func (NetAPIStub) PeerCount() hexutil.Uint {
+	return 3
+}
+
+type EthAPIStub struct {
+	chainID *big.Int
+}
+
+func (EthAPIStub) BlockNumber() hexutil.Uint64 {
+	return 42
+}
+
+func (api EthAPIStub) ChainId() (*hexutil.Big, error) {
+	if api.chainID == nil {
+		return nil, fmt.Errorf("the method eth_chainId does not exist/is not available")
+	}
+	return (*hexutil.Big)(api.chainID), nil
+}
+
+// start an in-process JSON-RPC server serving the stand-in APIs and return its HTTP endpoint
+func newTestRPCServer(t *testing.T, chainID *big.Int) string {
+	srv := rpc.NewServer()
+	for name, api := range map[string]interface{}{
+		"admin": AdminAPIStub{},
+		"net":   NetAPIStub{},
+		"eth":   EthAPIStub{chainID: chainID},
+	} {
+		if err := srv.RegisterName(name, api); err != nil {
+			t.Fatal(err)
+		}
+	}
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+	return httpSrv.URL
+}
+
+func TestToBlockNumberArg(t *testing.T) {
+	for block, expected := range map[string]string{
+		"latest":  "latest",
+		"pending": "pending",
+		"0":       "0x0",
+		"1024":    "0x400",
+		"0x400":   "0x400",
+	} {
+		actual, err := toBlockNumberArg(block)
+
+		assert.NoError(t, err, block)
+		assert.Equal(t, expected, actual, block)
+	}
+}
+
+func TestToBlockNumberArg_whenInvalid(t *testing.T) {
+	for _, block := range []string{"", "-1", "0x", "0xzz", "safe"} {
+		_, err := toBlockNumberArg(block)
+
+		assert.Error(t, err, block)
+	}
+}
+
+func TestConfigurerDialRPC_whenNoEndpoint(t *testing.T) {
+	_, err := (&configurer{}).dialRPC(context.Background(), "")
+
+	assert.Error(t, err)
+}
+
+func TestConfigurerDialRPC_whenUsingProviderEndpoint(t *testing.T) {
+	c, err := (&configurer{rpcEndpoint: newTestRPCServer(t, big.NewInt(10))}).dialRPC(context.Background(), "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer c.Close()
+	var n hexutil.Uint64
+
+	assert.NoError(t, c.CallContext(context.Background(), &n, "eth_blockNumber"))
+	assert.Equal(t, hexutil.Uint64(42), n)
+}
+
+// SimulatedEthAPI serves eth RPC API used by ethclient from a simulated backend. Transactions are mined immediately.
+// As the simulated backend can't execute private transactions, they are only verified and recorded
+// and a successful public receipt is returned the same way a node not being a participant does
+type SimulatedEthAPI struct {
+	backend *backends.SimulatedBackend
+
+	mux                 sync.Mutex
+	privateReceipts     map[common.Hash]*types.Receipt
+	privateTransactions []*simulatedPrivateTransaction
+}
+
+type simulatedPrivateTransaction struct {
+	tx         *types.Transaction
+	privateFor []string
+}
+
+// SimulatedCallArgs is the argument of eth_estimateGas
+type SimulatedCallArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+func (api *SimulatedEthAPI) GetTransactionCount(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (hexutil.Uint64, error) {
+	if blockNr == rpc.PendingBlockNumber {
+		nonce, err := api.backend.PendingNonceAt(ctx, address)
+		return hexutil.Uint64(nonce), err
+	}
+	nonce, err := api.backend.NonceAt(ctx, address, nil)
+	return hexutil.Uint64(nonce), err
+}
+
+func (api *SimulatedEthAPI) GetBalance(ctx context.Context, address common.Address, _ rpc.BlockNumber) (*hexutil.Big, error) {
+	balance, err := api.backend.BalanceAt(ctx, address, nil)
+	return (*hexutil.Big)(balance), err
+}
+
+func (api *SimulatedEthAPI) GetCode(ctx context.Context, address common.Address, _ rpc.BlockNumber) (hexutil.Bytes, error) {
+	return api.backend.CodeAt(ctx, address, nil)
+}
+
+// GasPrice is zero as in Quorum so accounts don't need funds
+func (api *SimulatedEthAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(common.Big0)
+}
+
+func (api *SimulatedEthAPI) EstimateGas(ctx context.Context, args SimulatedCallArgs) (hexutil.Uint64, error) {
+	gas, err := api.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:     args.From,
+		To:       args.To,
+		Gas:      uint64(args.Gas),
+		GasPrice: args.GasPrice.ToInt(),
+		Value:    args.Value.ToInt(),
+		Data:     args.Data,
+	})
+	return hexutil.Uint64(gas), err
+}
+
+func (api *SimulatedEthAPI) SendRawTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.backend.SendTransaction(ctx, tx, bind.PrivateTxArgs{}); err != nil {
+		return common.Hash{}, err
+	}
+	api.backend.Commit()
+	return tx.Hash(), nil
+}
+
+func (api *SimulatedEthAPI) SendRawPrivateTransaction(_ context.Context, encodedTx hexutil.Bytes, args bind.PrivateTxArgs) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	if !tx.IsPrivate() {
+		return common.Hash{}, fmt.Errorf("transaction is not private")
+	}
+	sender, err := types.Sender(types.QuorumPrivateTxSigner{}, tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}, TxHash: tx.Hash()}
+	if tx.To() == nil {
+		receipt.ContractAddress = crypto.CreateAddress(sender, tx.Nonce())
+	}
+	api.mux.Lock()
+	defer api.mux.Unlock()
+	api.privateReceipts[tx.Hash()] = receipt
+	api.privateTransactions = append(api.privateTransactions, &simulatedPrivateTransaction{tx: tx, privateFor: args.PrivateFor})
+	return tx.Hash(), nil
+}
+
+func (api *SimulatedEthAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	api.mux.Lock()
+	receipt, ok := api.privateReceipts[hash]
+	api.mux.Unlock()
+	if ok {
+		return receipt, nil
+	}
+	return api.backend.TransactionReceipt(ctx, hash)
+}
+
+// start an in-process JSON-RPC server serving eth RPC API from a simulated backend with the given genesis alloc
+// and return its HTTP endpoint
+func newTestSimulatedRPCServer(t *testing.T, alloc core.GenesisAlloc) (*SimulatedEthAPI, string) {
+	api := &SimulatedEthAPI{
+		backend:         backends.NewSimulatedBackend(alloc, 8000000),
+		privateReceipts: make(map[common.Hash]*types.Receipt),
+	}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		httpSrv.Close()
+		srv.Stop()
+	})
+	return api, httpSrv.URL
+}
@@ -0,0 +1,97 @@
+package quorum
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// tesseraUnixPrefix marks a Tessera endpoint as a unix socket path
+const tesseraUnixPrefix = "unix:"
+
+// tesseraPartyInfo is the response of Tessera /partyinfo
+type tesseraPartyInfo struct {
+	URL   string `json:"url"`
+	Peers []struct {
+		URL string `json:"url"`
+	} `json:"peers"`
+	Keys []struct {
+		Key string `json:"key"`
+		URL string `json:"url"`
+	} `json:"keys"`
+}
+
+// tesseraClient sends requests to a Tessera server over HTTP(S) or a unix socket
+type tesseraClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// newTesseraClient returns a client for an HTTP(S) URL or a unix socket path prefixed by unix:
+func newTesseraClient(endpoint string) (*tesseraClient, error) {
+	if strings.HasPrefix(endpoint, tesseraUnixPrefix) {
+		socket := strings.TrimPrefix(endpoint, tesseraUnixPrefix)
+		if socket == "" {
+			return nil, fmt.Errorf("unix socket path is empty")
+		}
+		return &tesseraClient{
+			httpClient: &http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						var dialer net.Dialer
+						return dialer.DialContext(ctx, "unix", socket)
+					},
+				},
+			},
+			// host is ignored when dialing the socket
+			baseURL: "http://localhost",
+		}, nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("URL [%s] must be http(s) or a unix socket path prefixed by %s", endpoint, tesseraUnixPrefix)
+	}
+	return &tesseraClient{
+		httpClient: &http.Client{},
+		baseURL:    strings.TrimSuffix(endpoint, "/"),
+	}, nil
+}
+
+// get returns the response body of a successful GET request to the path
+func (c *tesseraClient) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+func (c *tesseraClient) close() {
+	c.httpClient.CloseIdleConnections()
+}
+
+func validateTesseraURL(i interface{}, s string) (ws []string, es []error) {
+	if _, err := newTesseraClient(i.(string)); err != nil {
+		es = append(es, fmt.Errorf("%s is invalid due to %s", s, err))
+	}
+	return
+}
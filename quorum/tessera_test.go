@@ -0,0 +1,103 @@
+package quorum
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTesseraKey1 = "BULeR8JyUWhiuuCMU/HLA0Q5pzkYT+cHII3ZKBey3Bo="
+	testTesseraKey2 = "QfeDAys9MPDs2XHExtc84jKGHxZg/aj52DTh0vtA3Xc="
+)
+
+// stub Tessera node knowing itself and a peer, /upcheck fails unless up
+func newTestTesseraHandler(up bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upcheck", func(w http.ResponseWriter, _ *http.Request) {
+		if !up {
+			http.Error(w, "starting", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("I'm up!"))
+	})
+	mux.HandleFunc("/version", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("21.7.2"))
+	})
+	mux.HandleFunc("/partyinfo", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "http://tm1:9001/",
+			"peers": []map[string]string{
+				{"url": "http://tm2:9001/", "lastContact": "2021-01-01T00:00:00Z"},
+				{"url": "http://tm1:9001/", "lastContact": "2021-01-01T00:00:00Z"},
+			},
+			"keys": []map[string]string{
+				{"key": testTesseraKey2, "url": "http://tm2:9001/"},
+				{"key": testTesseraKey1, "url": "http://tm1:9001/"},
+			},
+		})
+	})
+	return mux
+}
+
+// serve the handler over a unix socket and return the endpoint prefixed by unix:
+func newTestUnixServer(t *testing.T, handler http.Handler) string {
+	socket := filepath.Join(t.TempDir(), "tm.ipc")
+	l, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: handler}
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+	return tesseraUnixPrefix + socket
+}
+
+func TestTesseraClient_whenUnixSocket(t *testing.T) {
+	client, err := newTesseraClient(newTestUnixServer(t, newTestTesseraHandler(true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.close()
+
+	body, err := client.get(context.Background(), "/upcheck")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "I'm up!", string(body))
+}
+
+func TestTesseraClient_whenNotOK(t *testing.T) {
+	srv := httptest.NewServer(newTestTesseraHandler(false))
+	defer srv.Close()
+	client, err := newTesseraClient(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.close()
+
+	_, err = client.get(context.Background(), "/upcheck")
+
+	assert.EqualError(t, err, "GET /upcheck returned 503 Service Unavailable: starting")
+}
+
+func TestNewTesseraClient_whenInvalid(t *testing.T) {
+	for name, endpoint := range map[string]string{
+		"no scheme":    "localhost:9001",
+		"not http":     "ftp://localhost:9001",
+		"empty socket": "unix:",
+	} {
+		_, err := newTesseraClient(endpoint)
+
+		assert.Error(t, err, name)
+	}
+}
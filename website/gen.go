@@ -16,9 +16,10 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/hashicorp/hcl2/hclwrite"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-quorum/quorum"
 )
 
@@ -48,12 +49,12 @@ func main() {
 		log.Fatal(err)
 	}
 	log.Println("Building index page...")
-	if err := generateIndexDocs(path.Join(basedir, "provider.go")); err != nil {
+	if err := generateIndexDocs(path.Join(basedir, "provider.go"), meta.Schema); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func generateIndexDocs(providerSourceFile string) error {
+func generateIndexDocs(providerSourceFile string, providerSchema map[string]*schema.Schema) error {
 	f, err := parser.ParseFile(token.NewFileSet(), providerSourceFile, nil, parser.ParseComments)
 	if err != nil {
 		return err
@@ -68,6 +69,7 @@ func generateIndexDocs(providerSourceFile string) error {
 	}
 	ctx := index{
 		ShortDescription: padding(f.Doc.Text(), "   "),
+		Inputs:           toInputSections(providerSchema),
 	}
 	for _, d := range f.Decls {
 		if fd, ok := d.(*ast.FuncDecl); ok {
@@ -88,7 +90,7 @@ func generate(prefix string, targetDir string, m map[string]*schema.Resource) ([
 	for n, s := range m {
 		sourceFile := fmt.Sprintf("%s_%s.go", prefix, n)
 		testSourceFile := fmt.Sprintf("%s_%s_test.go", prefix, n)
-		index, err := generatePageDoc(n, path.Join(basedir, sourceFile), path.Join(basedir, testSourceFile), path.Join(targetDocsDir, targetDir), s.Schema)
+		index, err := generatePageDoc(n, path.Join(basedir, sourceFile), path.Join(basedir, testSourceFile), path.Join(targetDocsDir, targetDir), s)
 		if err != nil {
 			return nil, err
 		}
@@ -121,7 +123,8 @@ func generateNavDocs(dsIndex []*nav, resIndex []*nav) error {
 	return nil
 }
 
-func generatePageDoc(pageName string, sourceFile string, testSourceFile string, targetDir string, dsSchema map[string]*schema.Schema) (*nav, error) {
+func generatePageDoc(pageName string, sourceFile string, testSourceFile string, targetDir string, res *schema.Resource) (*nav, error) {
+	dsSchema := res.Schema
 	log.Println("Parsing", sourceFile)
 	_ = os.MkdirAll(targetDir, 0755)
 	shortName := strings.TrimPrefix(pageName, "quorum_")
@@ -142,11 +145,11 @@ func generatePageDoc(pageName string, sourceFile string, testSourceFile string,
 		return nil, err
 	}
 	ctx := page{
-		Name:    pageName,
-		Title:   pageName,
-		SideBar: fmt.Sprintf("docs-%s", strings.ReplaceAll(pageName, "_", "-")),
-		Inputs:  make([]*pageInputSection, 0),
-		Outputs: make([]*pageOutputSection, 0),
+		Name:     pageName,
+		Title:    pageName,
+		SideBar:  fmt.Sprintf("docs-%s", strings.ReplaceAll(pageName, "_", "-")),
+		Outputs:  make([]*pageOutputSection, 0),
+		Timeouts: toTimeoutSections(res.Timeouts),
 	}
 	for _, d := range f.Decls {
 		if f, ok := d.(*ast.FuncDecl); ok {
@@ -170,13 +173,32 @@ func generatePageDoc(pageName string, sourceFile string, testSourceFile string,
 			}
 		}
 	}
+	ctx.Inputs = toInputSections(dsSchema)
 	for field, fschema := range dsSchema {
-		if fschema.Computed { // output
+		if fschema.Computed && !fschema.Optional { // output
 			ctx.Outputs = append(ctx.Outputs, &pageOutputSection{
 				Name:        field,
 				Description: fschema.Description,
 			})
-		} else { // input
+		}
+	}
+	sort.Slice(ctx.Outputs, func(i, j int) bool {
+		return ctx.Outputs[i].Name < ctx.Outputs[j].Name
+	})
+	if err := t.Execute(out, ctx); err != nil {
+		return nil, err
+	}
+	return &nav{
+		SideBarCurrent: ctx.SideBar,
+		PageName:       shortName,
+		Name:           ctx.Name,
+	}, nil
+}
+
+func toInputSections(m map[string]*schema.Schema) []*pageInputSection {
+	inputs := make([]*pageInputSection, 0)
+	for field, fschema := range m {
+		if !fschema.Computed || fschema.Optional { // input
 			flag := "Optional"
 			if fschema.Required {
 				flag = "Required"
@@ -215,27 +237,18 @@ func generatePageDoc(pageName string, sourceFile string, testSourceFile string,
 					}
 				}
 			}
-			ctx.Inputs = append(ctx.Inputs, section)
+			inputs = append(inputs, section)
 		}
 	}
-	sort.Slice(ctx.Inputs, func(i, j int) bool {
-		return ctx.Inputs[i].Name < ctx.Inputs[j].Name
-	})
-	sort.Slice(ctx.Outputs, func(i, j int) bool {
-		return ctx.Outputs[i].Name < ctx.Outputs[j].Name
+	sort.Slice(inputs, func(i, j int) bool {
+		return inputs[i].Name < inputs[j].Name
 	})
-	if err := t.Execute(out, ctx); err != nil {
-		return nil, err
-	}
-	return &nav{
-		SideBarCurrent: ctx.SideBar,
-		PageName:       shortName,
-		Name:           ctx.Name,
-	}, nil
+	return inputs
 }
 
 type index struct {
 	ShortDescription, LongDescription string
+	Inputs                            []*pageInputSection
 }
 
 type nav struct {
@@ -253,6 +266,7 @@ type page struct {
 	Example          string
 	Inputs           []*pageInputSection
 	Outputs          []*pageOutputSection
+	Timeouts         []*pageTimeoutSection
 }
 
 type pageInputObject struct {
@@ -269,6 +283,34 @@ type pageOutputSection struct {
 	Name, Description string
 }
 
+type pageTimeoutSection struct {
+	Name, Default string
+}
+
+func toTimeoutSections(t *schema.ResourceTimeout) []*pageTimeoutSection {
+	timeouts := make([]*pageTimeoutSection, 0)
+	if t == nil {
+		return timeouts
+	}
+	for _, v := range []struct {
+		name    string
+		timeout *time.Duration
+	}{
+		{"create", t.Create},
+		{"read", t.Read},
+		{"update", t.Update},
+		{"delete", t.Delete},
+	} {
+		if v.timeout != nil {
+			timeouts = append(timeouts, &pageTimeoutSection{
+				Name:    v.name,
+				Default: v.timeout.String(),
+			})
+		}
+	}
+	return timeouts
+}
+
 type exampleCaptor string
 
 func (c *exampleCaptor) Visit(n ast.Node) ast.Visitor {